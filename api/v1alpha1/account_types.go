@@ -17,25 +17,53 @@ limitations under the License.
 package v1alpha1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // AccountSpec defines the desired state of Account
 type AccountSpec struct {
+	// ApiKeySecretRef selects the key of a Secret in the Account's namespace holding the UptimeRobot api key.
+	// When unset the operator's default api key is used.
+	// +optional
+	ApiKeySecretRef *corev1.SecretKeySelector `json:"apiKeySecretRef,omitempty"`
+	// PauseMonitors pauses every Monitor that references this Account, e.g. during a planned outage
+	// +optional
+	PauseMonitors bool `json:"pauseMonitors,omitempty"`
 }
 
 // AccountStatus defines the observed state of Account
 type AccountStatus struct {
-	Email           string `json:"email"`
-	MonitorLimit    int    `json:"monitorLimit"`
-	MonitorInterval int    `json:"monitorInterval"`
-	UpMonitors      int    `json:"upMonitors"`
-	DownMonitors    int    `json:"downMonitors"`
-	PausedMonitors  int    `json:"pausedMonitors"`
+	Email        string `json:"email"`
+	MonitorLimit int    `json:"monitorLimit"`
+	// MonitorInterval is the shortest check interval, in minutes, the account's plan allows
+	MonitorInterval int `json:"monitorInterval"`
+	UpMonitors      int `json:"upMonitors"`
+	DownMonitors    int `json:"downMonitors"`
+	PausedMonitors  int `json:"pausedMonitors"`
+	// AlertThresholdsSupported is true when the account's plan stores the threshold and recurrence of the
+	// contacts a monitor alerts, free plans notify them as soon as the monitor goes down
+	// +optional
+	AlertThresholdsSupported bool `json:"alertThresholdsSupported,omitempty"`
+	// ObservedGeneration is the generation of the spec last synced with UptimeRobot
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// LastSyncTime is when the resource was last synced with UptimeRobot
+	// +optional
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Email",type=string,JSONPath=`.status.email`
+//+kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+//+kubebuilder:printcolumn:name="Synced",type=string,JSONPath=`.status.conditions[?(@.type=="Synced")].status`
+//+kubebuilder:printcolumn:name="Last Sync",type=date,JSONPath=`.status.lastSyncTime`
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 
 // Account is the Schema for the accounts API
 type Account struct {
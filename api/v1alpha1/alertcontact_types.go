@@ -17,6 +17,7 @@ limitations under the License.
 package v1alpha1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -43,24 +44,53 @@ const (
 )
 
 // AlertContactSpec defines the desired state of AlertContact
+// +kubebuilder:validation:XValidation:rule="has(self.accountRef) == has(oldSelf.accountRef) && (!has(self.accountRef) || self.accountRef == oldSelf.accountRef)",message="accountRef is immutable"
+// +kubebuilder:validation:XValidation:rule="has(self.value) != has(self.valueFrom)",message="exactly one of value and valueFrom must be set"
 type AlertContactSpec struct {
+	// AccountRef names the Account in the same namespace that owns this AlertContact.
+	// When unset the operator's default api key is used.
+	// +optional
+	AccountRef *corev1.LocalObjectReference `json:"accountRef,omitempty"`
 	// Name is a friendly name for your AlertContact
-	Name  string           `json:"name"`
-	Type  AlertContactType `json:"type"`
-	Value string           `json:"value"`
+	Name string           `json:"name"`
+	Type AlertContactType `json:"type"`
+	// Value is the address, number or webhook url alerts are sent to
+	// +optional
+	Value string `json:"value,omitempty"`
+	// ValueFrom reads the value from a Secret instead, keeping it out of the AlertContact
+	// +optional
+	ValueFrom *ValueFromSource `json:"valueFrom,omitempty"`
 }
 
 // AlertContactStatus defines the observed state of AlertContact
 type AlertContactStatus struct {
-	Id     string           `json:"id"`
-	Name   string           `json:"name"`
-	Type   AlertContactType `json:"type"`
-	Value  string           `json:"value"`
-	Status int              `json:"status"`
+	Id   string           `json:"id"`
+	Name string           `json:"name"`
+	Type AlertContactType `json:"type"`
+	// ValueHash is a hash of the value last applied on UptimeRobot, the value itself isn't shown
+	// +optional
+	ValueHash string `json:"valueHash,omitempty"`
+	Status    int    `json:"status"`
+	// ObservedGeneration is the generation of the spec last synced with UptimeRobot
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// LastSyncTime is when the resource was last synced with UptimeRobot
+	// +optional
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Type",type=string,JSONPath=`.status.type`
+//+kubebuilder:printcolumn:name="Id",type=string,JSONPath=`.status.id`
+//+kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+//+kubebuilder:printcolumn:name="Synced",type=string,JSONPath=`.status.conditions[?(@.type=="Synced")].status`
+//+kubebuilder:printcolumn:name="Last Sync",type=date,JSONPath=`.status.lastSyncTime`
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 
 // AlertContact is the Schema for the alertcontacts API
 type AlertContact struct {
@@ -0,0 +1,44 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+// Condition types shared by every resource that is synced with UptimeRobot
+const (
+	// CONDITION_READY is true once the object exists on UptimeRobot as specified
+	CONDITION_READY = "Ready"
+	// CONDITION_SYNCED is true when the last attempt to sync the object with UptimeRobot succeeded
+	CONDITION_SYNCED = "Synced"
+	// CONDITION_DEGRADED is true when the object works on UptimeRobot but not quite as specified
+	CONDITION_DEGRADED = "Degraded"
+	// CONDITION_REMOTE_MISSING is true when the object was removed from UptimeRobot outside of the operator
+	CONDITION_REMOTE_MISSING = "RemoteMissing"
+)
+
+// Condition reasons
+const (
+	REASON_CREATED        = "Created"
+	REASON_UPDATED        = "Updated"
+	REASON_IN_SYNC        = "InSync"
+	REASON_AS_EXPECTED    = "AsExpected"
+	REASON_NOT_SYNCED     = "NotSynced"
+	REASON_RATE_LIMITED   = "RateLimited"
+	REASON_UNAUTHORIZED   = "Unauthorized"
+	REASON_INVALID_CONFIG = "InvalidConfig"
+	REASON_API_ERROR      = "ApiError"
+	REASON_PRESENT        = "Present"
+	REASON_RECREATED      = "Recreated"
+)
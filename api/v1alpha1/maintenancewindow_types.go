@@ -0,0 +1,124 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +kubebuilder:validation:Enum=once;daily;weekly;monthly
+type MaintenanceWindowType string
+
+const (
+	MAINTENANCE_ONCE    MaintenanceWindowType = "once"
+	MAINTENANCE_DAILY   MaintenanceWindowType = "daily"
+	MAINTENANCE_WEEKLY  MaintenanceWindowType = "weekly"
+	MAINTENANCE_MONTHLY MaintenanceWindowType = "monthly"
+)
+
+// MaintenanceWindowSpec defines the desired state of MaintenanceWindow
+// +kubebuilder:validation:XValidation:rule="has(self.accountRef) == has(oldSelf.accountRef) && (!has(self.accountRef) || self.accountRef == oldSelf.accountRef)",message="accountRef is immutable"
+// +kubebuilder:validation:XValidation:rule="self.type == 'once' ? has(self.date) : !has(self.date)",message="date must be set for, and only for, once windows"
+// +kubebuilder:validation:XValidation:rule="self.type == 'weekly' || self.type == 'monthly' ? has(self.days) : !has(self.days)",message="days must be set for, and only for, weekly and monthly windows"
+// +kubebuilder:validation:XValidation:rule="self.type != 'weekly' || !has(self.days) || self.days.all(day, day <= 7)",message="days of weekly windows run from 1, Monday, to 7, Sunday"
+// +kubebuilder:validation:XValidation:rule="self.type == 'once' || !has(self.timezone)",message="timezone can only be set for once windows, recurring windows run in the timezone of the account's settings"
+type MaintenanceWindowSpec struct {
+	// AccountRef names the Account in the same namespace that owns this MaintenanceWindow.
+	// When unset the operator's default api key is used.
+	// +optional
+	AccountRef *corev1.LocalObjectReference `json:"accountRef,omitempty"`
+	// Name is a friendly name for your MaintenanceWindow
+	Name string `json:"name"`
+	// Type is how often the window recurs, it can't be changed once the window exists
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="type is immutable"
+	Type MaintenanceWindowType `json:"type"`
+	// Date is the day, as YYYY-MM-DD, a once window happens on
+	// +kubebuilder:validation:Pattern=`^[0-9]{4}-[0-9]{2}-[0-9]{2}$`
+	// +optional
+	Date string `json:"date,omitempty"`
+	// Days are the days of the week, 1 to 7 from Monday, or of the month, 1 to 31, that weekly
+	// and monthly windows start on
+	// +kubebuilder:validation:MinItems=1
+	// +listType=set
+	// +optional
+	Days []MaintenanceWindowDay `json:"days,omitempty"`
+	// Start is the time, as HH:mm, the window starts at
+	// +kubebuilder:validation:Pattern=`^([01][0-9]|2[0-3]):[0-5][0-9]$`
+	Start string `json:"start"`
+	// Duration is how long, in minutes, the window lasts
+	// +kubebuilder:validation:Minimum=1
+	Duration int `json:"duration"`
+	// Timezone is the IANA time zone date and start of a once window are given in, UTC when unset.
+	// UptimeRobot runs recurring windows in the timezone of the account's settings, so they can't set it.
+	// +optional
+	Timezone string `json:"timezone,omitempty"`
+}
+
+// +kubebuilder:validation:Minimum=1
+// +kubebuilder:validation:Maximum=31
+type MaintenanceWindowDay int
+
+// MaintenanceWindowStatus defines the observed state of MaintenanceWindow
+type MaintenanceWindowStatus struct {
+	Id   string                `json:"id"`
+	Name string                `json:"name"`
+	Type MaintenanceWindowType `json:"type,omitempty"`
+	// Status is 1 while the window is active on UptimeRobot and 0 while it is paused
+	Status int `json:"status"`
+	// ObservedGeneration is the generation of the spec last synced with UptimeRobot
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// LastSyncTime is when the resource was last synced with UptimeRobot
+	// +optional
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Type",type=string,JSONPath=`.status.type`
+//+kubebuilder:printcolumn:name="Id",type=string,JSONPath=`.status.id`
+//+kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+//+kubebuilder:printcolumn:name="Synced",type=string,JSONPath=`.status.conditions[?(@.type=="Synced")].status`
+//+kubebuilder:printcolumn:name="Last Sync",type=date,JSONPath=`.status.lastSyncTime`
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// MaintenanceWindow is the Schema for the maintenancewindows API
+type MaintenanceWindow struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   MaintenanceWindowSpec   `json:"spec,omitempty"`
+	Status MaintenanceWindowStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// MaintenanceWindowList contains a list of MaintenanceWindow
+type MaintenanceWindowList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []MaintenanceWindow `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&MaintenanceWindow{}, &MaintenanceWindowList{})
+}
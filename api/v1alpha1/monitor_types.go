@@ -17,28 +17,407 @@ limitations under the License.
 package v1alpha1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
 // NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
 
+// +kubebuilder:validation:Enum=http;keyword;ping;port;heartbeat
+type MonitorType string
+
+const (
+	HTTP      MonitorType = "http"
+	KEYWORD   MonitorType = "keyword"
+	PING      MonitorType = "ping"
+	PORT      MonitorType = "port"
+	HEARTBEAT MonitorType = "heartbeat"
+)
+
+// +kubebuilder:validation:Enum=exists;not-exists
+type KeywordType string
+
+const (
+	KEYWORD_EXISTS     KeywordType = "exists"
+	KEYWORD_NOT_EXISTS KeywordType = "not-exists"
+)
+
+// KeywordConfig configures a keyword monitor
+type KeywordConfig struct {
+	// Type is whether the monitor alerts when the keyword exists or when it doesn't
+	Type KeywordType `json:"type"`
+	// Value is the keyword, given inline or read from a Secret
+	ValueOrSecret `json:",inline"`
+	// +optional
+	CaseSensitive bool `json:"caseSensitive,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=http;https;ftp;smtp;pop3;imap;custom
+type PortSubType string
+
+const (
+	PORT_HTTP   PortSubType = "http"
+	PORT_HTTPS  PortSubType = "https"
+	PORT_FTP    PortSubType = "ftp"
+	PORT_SMTP   PortSubType = "smtp"
+	PORT_POP3   PortSubType = "pop3"
+	PORT_IMAP   PortSubType = "imap"
+	PORT_CUSTOM PortSubType = "custom"
+)
+
+// PortConfig configures a port monitor
+// +kubebuilder:validation:XValidation:rule="self.subType == 'custom' ? has(self.port) : !has(self.port)",message="port must be set for, and only for, the custom sub type"
+type PortConfig struct {
+	// SubType is the service to check, custom checks any port
+	SubType PortSubType `json:"subType"`
+	// Port is the port to check for the custom sub type, the others use their well known port
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +optional
+	Port int `json:"port,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=HEAD;GET;POST;PUT;PATCH;DELETE;OPTIONS
+type HttpMethod string
+
+const (
+	HTTP_HEAD    HttpMethod = "HEAD"
+	HTTP_GET     HttpMethod = "GET"
+	HTTP_POST    HttpMethod = "POST"
+	HTTP_PUT     HttpMethod = "PUT"
+	HTTP_PATCH   HttpMethod = "PATCH"
+	HTTP_DELETE  HttpMethod = "DELETE"
+	HTTP_OPTIONS HttpMethod = "OPTIONS"
+)
+
+// +kubebuilder:validation:Enum=text/html;application/json
+type HttpContentType string
+
+const (
+	CONTENT_TYPE_HTML HttpContentType = "text/html"
+	CONTENT_TYPE_JSON HttpContentType = "application/json"
+)
+
+// HttpBody is the body sent with each check, either as form fields or as raw data
+// +kubebuilder:validation:XValidation:rule="has(self.form) != has(self.raw)",message="exactly one of form and raw must be set"
+type HttpBody struct {
+	// Form fields are sent as key-value pairs
+	// +optional
+	Form map[string]string `json:"form,omitempty"`
+	// Raw is sent as is
+	// +optional
+	Raw string `json:"raw,omitempty"`
+	// ContentType is the content type the body is sent with
+	// +kubebuilder:default=text/html
+	// +optional
+	ContentType HttpContentType `json:"contentType,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=basic;digest
+type HttpAuthType string
+
+const (
+	HTTP_AUTH_BASIC  HttpAuthType = "basic"
+	HTTP_AUTH_DIGEST HttpAuthType = "digest"
+)
+
+// HttpAuth are the credentials sent with each check
+type HttpAuth struct {
+	// +kubebuilder:default=basic
+	// +optional
+	Type     HttpAuthType  `json:"type,omitempty"`
+	Username ValueOrSecret `json:"username"`
+	Password SecretValue   `json:"password"`
+}
+
+// HttpHeader is a header sent with each check, its value given inline or read from a Secret
+type HttpHeader struct {
+	Name          string `json:"name"`
+	ValueOrSecret `json:",inline"`
+}
+
+// HttpStatuses override which response status codes count as up and which as down
+type HttpStatuses struct {
+	// +optional
+	Up []HttpStatusCode `json:"up,omitempty"`
+	// +optional
+	Down []HttpStatusCode `json:"down,omitempty"`
+}
+
+// +kubebuilder:validation:Minimum=100
+// +kubebuilder:validation:Maximum=599
+type HttpStatusCode int
+
+// HttpConfig customises the request http and keyword monitors make
+// +kubebuilder:validation:XValidation:rule="!has(self.body) || !has(self.method) || (self.method != 'GET' && self.method != 'HEAD')",message="body can't be sent with GET or HEAD requests"
+type HttpConfig struct {
+	// +optional
+	Method HttpMethod `json:"method,omitempty"`
+	// +optional
+	Body *HttpBody `json:"body,omitempty"`
+	// Headers are sent with each check
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Headers []HttpHeader `json:"headers,omitempty"`
+	// +optional
+	Statuses *HttpStatuses `json:"statuses,omitempty"`
+	// +optional
+	Auth *HttpAuth `json:"auth,omitempty"`
+}
+
+// SslConfig configures how http and keyword monitors treat the certificate of https urls
+type SslConfig struct {
+	// IgnoreErrors keeps the monitor up when the certificate is invalid or expired
+	// +optional
+	IgnoreErrors bool `json:"ignoreErrors,omitempty"`
+	// ExpirationReminder alerts the monitor's alert contacts before the certificate expires
+	// +optional
+	ExpirationReminder bool `json:"expirationReminder,omitempty"`
+}
+
+// AlertRoute delays and repeats the notifications of the alert contacts it selects, e.g. so a pager
+// only fires after a sustained outage
+type AlertRoute struct {
+	// AlertContacts selects the AlertContacts the route applies to
+	AlertContacts metav1.LabelSelector `json:"alertContacts"`
+	// Threshold is how many minutes the monitor is down before the contacts are notified
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Threshold int `json:"threshold,omitempty"`
+	// Recurrence is how often, in minutes, the contacts are notified again while the monitor stays down.
+	// They are notified once when it is 0.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Recurrence int `json:"recurrence,omitempty"`
+}
+
 // MonitorSpec defines the desired state of Monitor
+// +kubebuilder:validation:XValidation:rule="has(self.accountRef) == has(oldSelf.accountRef) && (!has(self.accountRef) || self.accountRef == oldSelf.accountRef)",message="accountRef is immutable"
+// +kubebuilder:validation:XValidation:rule="self.type == 'heartbeat' || (has(self.url) && size(self.url) > 0)",message="url is required unless type is heartbeat"
+// +kubebuilder:validation:XValidation:rule="self.type == 'keyword' ? has(self.keyword) : !has(self.keyword)",message="keyword must be set for, and only for, keyword monitors"
+// +kubebuilder:validation:XValidation:rule="self.type == 'port' ? has(self.port) : !has(self.port)",message="port must be set for, and only for, port monitors"
+// +kubebuilder:validation:XValidation:rule="!has(self.http) || self.type == 'http' || self.type == 'keyword'",message="http can only be set for http and keyword monitors"
+// +kubebuilder:validation:XValidation:rule="!has(self.ssl) || self.type == 'http' || self.type == 'keyword'",message="ssl can only be set for http and keyword monitors"
 type MonitorSpec struct {
-	Name          string               `json:"name"`
-	Url           string               `json:"url"`
+	// AccountRef names the Account in the same namespace that owns this Monitor.
+	// When unset the operator's default api key is used.
+	// +optional
+	AccountRef *corev1.LocalObjectReference `json:"accountRef,omitempty"`
+	Name       string                       `json:"name"`
+	// Type is the kind of check UptimeRobot runs, it can't be changed once the monitor exists
+	// +kubebuilder:default=http
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="type is immutable"
+	// +optional
+	Type MonitorType `json:"type,omitempty"`
+	// Url is the url, or for ping and port monitors the host, to check. Heartbeat monitors don't have one.
+	// +optional
+	Url string `json:"url,omitempty"`
+	// Keyword configures keyword monitors
+	// +optional
+	Keyword *KeywordConfig `json:"keyword,omitempty"`
+	// Port configures port monitors
+	// +optional
+	Port *PortConfig `json:"port,omitempty"`
+	// Http customises the request of http and keyword monitors
+	// +optional
+	Http *HttpConfig `json:"http,omitempty"`
+	// Ssl configures the certificate checks of https urls
+	// +optional
+	Ssl *SslConfig `json:"ssl,omitempty"`
+	// DisableDomainExpiryNotifications stops UptimeRobot alerting before the url's domain expires
+	// +optional
+	DisableDomainExpiryNotifications bool `json:"disableDomainExpiryNotifications,omitempty"`
+	// Interval is how often, in seconds, the monitor is checked. It is raised to the
+	// minimum interval of the account's plan when it is lower.
+	// +kubebuilder:default=300
+	// +kubebuilder:validation:Minimum=30
+	// +optional
+	Interval int `json:"interval,omitempty"`
+	// Timeout is how long, in seconds, a check waits for a response. Only http, keyword and port monitors use it.
+	// +kubebuilder:default=30
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=60
+	// +optional
+	Timeout int `json:"timeout,omitempty"`
+	// AlertContacts selects the AlertContacts notified as soon as the monitor goes down. An empty selector
+	// selects every AlertContact.
+	// +optional
 	AlertContacts metav1.LabelSelector `json:"alertContacts,omitempty"`
+	// AlertContactNamespaces selects further namespaces, besides the Monitor's own, that alertContacts and
+	// alertRoutes select AlertContacts in. The contacts have to belong to the Monitor's UptimeRobot account.
+	// +optional
+	AlertContactNamespaces *metav1.LabelSelector `json:"alertContactNamespaces,omitempty"`
+	// AlertRoutes notify the contacts they select after the monitor is down for their threshold, and
+	// again on their recurrence. A contact uses the first route selecting it, contacts selected only by
+	// alertContacts are notified as soon as the monitor goes down.
+	// +kubebuilder:validation:MaxItems=20
+	// +optional
+	AlertRoutes []AlertRoute `json:"alertRoutes,omitempty"`
+	// MaintenanceWindows selects the MaintenanceWindows in the Monitor's namespace during which it isn't checked
+	// +optional
+	MaintenanceWindows metav1.LabelSelector `json:"maintenanceWindows,omitempty"`
+	// UptimeRatioPeriods are the periods, in days, the status reports uptime ratios for
+	// +kubebuilder:default={1,7,30}
+	// +kubebuilder:validation:MaxItems=10
+	// +listType=set
+	// +optional
+	UptimeRatioPeriods []UptimeRatioPeriod `json:"uptimeRatioPeriods,omitempty"`
+	// Paused stops UptimeRobot checking the monitor until it is unset
+	// +optional
+	Paused bool `json:"paused,omitempty"`
+}
+
+const (
+	// CONDITION_INTERVAL_ACCEPTED is false when spec.interval was raised to the account's minimum
+	CONDITION_INTERVAL_ACCEPTED = "IntervalAccepted"
+
+	REASON_INTERVAL_ACCEPTED     = "Accepted"
+	REASON_BELOW_ACCOUNT_MINIMUM = "BelowAccountMinimum"
+
+	// CONDITION_ALERT_ROUTES_ACCEPTED is false when the account's plan doesn't store the threshold and
+	// recurrence spec.alertRoutes give contacts
+	CONDITION_ALERT_ROUTES_ACCEPTED = "AlertRoutesAccepted"
+
+	REASON_ALERT_ROUTES_ACCEPTED = "Accepted"
+	REASON_UNSUPPORTED_BY_PLAN   = "UnsupportedByPlan"
+
+	// CONDITION_PAUSED is true while the monitor is paused, its reason says what paused it
+	CONDITION_PAUSED = "Paused"
+
+	REASON_NOT_PAUSED           = "NotPaused"
+	REASON_PAUSED_BY_SPEC       = "PausedBySpec"
+	REASON_PAUSED_BY_ANNOTATION = "PausedByAnnotation"
+	REASON_PAUSED_BY_ACCOUNT    = "PausedByAccount"
+	REASON_PAUSED_BY_NAMESPACE  = "PausedByNamespace"
+)
+
+// ANNOTATION_PAUSED set to "true" on a Monitor, or on its Namespace, pauses the monitor without editing
+// its spec, e.g. during an incident or a planned outage
+const ANNOTATION_PAUSED = "uptimerobot.com/paused"
+
+// MonitorState is the health of a monitor as last checked by UptimeRobot, unknown while the api
+// doesn't report the monitor
+// +kubebuilder:validation:Enum=not-checked;up;seems-down;down;paused;unknown
+type MonitorState string
+
+const (
+	STATE_NOT_CHECKED MonitorState = "not-checked"
+	STATE_UP          MonitorState = "up"
+	STATE_SEEMS_DOWN  MonitorState = "seems-down"
+	STATE_DOWN        MonitorState = "down"
+	STATE_PAUSED      MonitorState = "paused"
+	STATE_UNKNOWN     MonitorState = "unknown"
+)
+
+// UptimeRatioPeriod is a number of days, ending now, to report the uptime ratio of
+// +kubebuilder:validation:Minimum=1
+// +kubebuilder:validation:Maximum=365
+type UptimeRatioPeriod int
+
+// UptimeRatio is the percentage of a period a monitor was up
+type UptimeRatio struct {
+	// Days is the length of the period, ending now
+	Days int `json:"days"`
+	// Ratio is the percentage, e.g. "99.985"
+	Ratio string `json:"ratio"`
+}
+
+// MonitorDowntime is a period a monitor was down
+type MonitorDowntime struct {
+	// Start is when the monitor went down
+	Start metav1.Time `json:"start"`
+	// Duration is how long the monitor was down, or has been down so far
+	Duration metav1.Duration `json:"duration"`
+	// Reason is why UptimeRobot considered the monitor down
+	// +optional
+	Reason string `json:"reason,omitempty"`
+}
+
+// ResolvedAlertContact is an AlertContact a monitor notifies
+type ResolvedAlertContact struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Id        string `json:"id"`
+	// Threshold is how many minutes the monitor is down before the contact is notified
+	// +optional
+	Threshold int `json:"threshold,omitempty"`
+	// Recurrence is how often, in minutes, the contact is notified again while the monitor stays down
+	// +optional
+	Recurrence int `json:"recurrence,omitempty"`
 }
 
 // MonitorStatus defines the observed state of Monitor
 type MonitorStatus struct {
-	Id   string `json:"id"`
-	Name string `json:"name"`
-	Url  string `json:"url"`
+	Id   string      `json:"id"`
+	Name string      `json:"name"`
+	Type MonitorType `json:"type,omitempty"`
+	Url  string      `json:"url"`
+	// HeartbeatUrl is the url a heartbeat monitor expects to be requested at
+	// +optional
+	HeartbeatUrl string `json:"heartbeatUrl,omitempty"`
+	// Interval is the check interval, in seconds, in use on UptimeRobot
+	// +optional
+	Interval int `json:"interval,omitempty"`
+	// HttpSettingsHash is a hash of the http, ssl and domain expiry settings last sent to UptimeRobot, which can't read them back.
+	// It changes when a Secret they are read from is rotated.
+	// +optional
+	HttpSettingsHash string `json:"httpSettingsHash,omitempty"`
+	// AlertContacts are the AlertContacts the selectors resolved to, and when each is notified
+	// +optional
+	AlertContacts []ResolvedAlertContact `json:"alertContacts,omitempty"`
+	// MaintenanceWindows are the ids of the maintenance windows attached to the monitor
+	// +optional
+	MaintenanceWindows []string `json:"maintenanceWindows,omitempty"`
+	// State is the health of the monitor as last checked by UptimeRobot
+	// +optional
+	State MonitorState `json:"state,omitempty"`
+	// LastStateChange is when the monitor last went up, down, was paused or started
+	// +optional
+	LastStateChange *metav1.Time `json:"lastStateChange,omitempty"`
+	// LastDowntime is the most recent period the monitor was down
+	// +optional
+	LastDowntime *MonitorDowntime `json:"lastDowntime,omitempty"`
+	// SslExpiry is when the certificate of an https url expires
+	// +optional
+	SslExpiry *metav1.Time `json:"sslExpiry,omitempty"`
+	// SslIssuer is the brand of the certificate of an https url
+	// +optional
+	SslIssuer string `json:"sslIssuer,omitempty"`
+	// UptimeRatios are the uptime percentages of the spec's uptimeRatioPeriods
+	// +optional
+	// +listType=map
+	// +listMapKey=days
+	UptimeRatios []UptimeRatio `json:"uptimeRatios,omitempty"`
+	// AllTimeUptimeRatio is the uptime percentage since the monitor was created
+	// +optional
+	AllTimeUptimeRatio string `json:"allTimeUptimeRatio,omitempty"`
+	// AverageResponseTime is the average response time, in milliseconds, over the last day
+	// +optional
+	AverageResponseTime string `json:"averageResponseTime,omitempty"`
+	// ObservedGeneration is the generation of the spec last synced with UptimeRobot
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// LastSyncTime is when the resource was last synced with UptimeRobot
+	// +optional
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Type",type=string,JSONPath=`.status.type`
+//+kubebuilder:printcolumn:name="Url",type=string,JSONPath=`.status.url`
+//+kubebuilder:printcolumn:name="Id",type=string,JSONPath=`.status.id`
+//+kubebuilder:printcolumn:name="State",type=string,JSONPath=`.status.state`
+//+kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+//+kubebuilder:printcolumn:name="Synced",type=string,JSONPath=`.status.conditions[?(@.type=="Synced")].status`
+//+kubebuilder:printcolumn:name="Last Sync",type=date,JSONPath=`.status.lastSyncTime`
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 
 // Monitor is the Schema for the monitors API
 type Monitor struct {
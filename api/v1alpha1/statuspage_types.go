@@ -0,0 +1,120 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +kubebuilder:validation:Enum=a-z;z-a;up-down-paused;down-up-paused
+type StatusPageSort string
+
+const (
+	SORT_A_Z            StatusPageSort = "a-z"
+	SORT_Z_A            StatusPageSort = "z-a"
+	SORT_UP_DOWN_PAUSED StatusPageSort = "up-down-paused"
+	SORT_DOWN_UP_PAUSED StatusPageSort = "down-up-paused"
+)
+
+// StatusPageSpec defines the desired state of StatusPage
+// +kubebuilder:validation:XValidation:rule="has(self.accountRef) == has(oldSelf.accountRef) && (!has(self.accountRef) || self.accountRef == oldSelf.accountRef)",message="accountRef is immutable"
+type StatusPageSpec struct {
+	// AccountRef names the Account in the same namespace that owns this StatusPage.
+	// When unset the operator's default api key is used.
+	// +optional
+	AccountRef *corev1.LocalObjectReference `json:"accountRef,omitempty"`
+	// Name is a friendly name for your StatusPage
+	Name string `json:"name"`
+	// Monitors selects the Monitors in the StatusPage's namespace the page shows
+	Monitors metav1.LabelSelector `json:"monitors"`
+	// CustomDomain serves the page on your own domain, which needs a CNAME record pointing at UptimeRobot
+	// +optional
+	CustomDomain string `json:"customDomain,omitempty"`
+	// Password protects the page, it is read from a Secret
+	// +optional
+	Password *SecretValue `json:"password,omitempty"`
+	// Sort is the order monitors are listed in, by name or by status
+	// +kubebuilder:default=a-z
+	// +optional
+	Sort StatusPageSort `json:"sort,omitempty"`
+	// HideUrlLinks hides the urls of the monitors on the page
+	// +optional
+	HideUrlLinks bool `json:"hideUrlLinks,omitempty"`
+}
+
+// StatusPageStatus defines the observed state of StatusPage
+type StatusPageStatus struct {
+	Id   string `json:"id"`
+	Name string `json:"name"`
+	// Url is where UptimeRobot serves the page
+	// +optional
+	Url string `json:"url,omitempty"`
+	// CustomUrl is where the page is served on the custom domain
+	// +optional
+	CustomUrl string `json:"customUrl,omitempty"`
+	// Monitors are the ids of the monitors the page shows
+	// +optional
+	Monitors []string `json:"monitors,omitempty"`
+	// SettingsHash is a hash of the custom domain, password and url settings last sent to UptimeRobot,
+	// which can't read them back. It changes when the password's Secret is rotated.
+	// +optional
+	SettingsHash string `json:"settingsHash,omitempty"`
+	// Status is 1 while the page is active on UptimeRobot and 0 while it is paused
+	Status int `json:"status"`
+	// ObservedGeneration is the generation of the spec last synced with UptimeRobot
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// LastSyncTime is when the resource was last synced with UptimeRobot
+	// +optional
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Url",type=string,JSONPath=`.status.url`
+//+kubebuilder:printcolumn:name="Id",type=string,JSONPath=`.status.id`
+//+kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+//+kubebuilder:printcolumn:name="Synced",type=string,JSONPath=`.status.conditions[?(@.type=="Synced")].status`
+//+kubebuilder:printcolumn:name="Last Sync",type=date,JSONPath=`.status.lastSyncTime`
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// StatusPage is the Schema for the statuspages API
+type StatusPage struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   StatusPageSpec   `json:"spec,omitempty"`
+	Status StatusPageStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// StatusPageList contains a list of StatusPage
+type StatusPageList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []StatusPage `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&StatusPage{}, &StatusPageList{})
+}
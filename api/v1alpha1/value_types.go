@@ -0,0 +1,43 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+// LABEL_SECRET set to "true" on a Secret lets the operator read it, only Secrets labelled so are cached
+const LABEL_SECRET = "uptimerobot.com/secret"
+
+// ValueFromSource reads a value from a Secret in the resource's namespace
+type ValueFromSource struct {
+	SecretKeyRef corev1.SecretKeySelector `json:"secretKeyRef"`
+}
+
+// ValueOrSecret is a value given either inline or read from a Secret
+// +kubebuilder:validation:XValidation:rule="has(self.value) != has(self.valueFrom)",message="exactly one of value and valueFrom must be set"
+type ValueOrSecret struct {
+	// +optional
+	Value string `json:"value,omitempty"`
+	// +optional
+	ValueFrom *ValueFromSource `json:"valueFrom,omitempty"`
+}
+
+// SecretValue is a value that must be read from a Secret
+type SecretValue struct {
+	ValueFrom ValueFromSource `json:"valueFrom"`
+}
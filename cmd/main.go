@@ -17,17 +17,26 @@ limitations under the License.
 package main
 
 import (
+	"context"
+	"errors"
 	"flag"
+	"net/url"
 	"os"
+	"time"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
@@ -51,15 +60,58 @@ func init() {
 	//+kubebuilder:scaffold:scheme
 }
 
+func envOrDefault(name string, defaultValue string) string {
+	value, ok := os.LookupEnv(name)
+	if !ok || value == "" {
+		return defaultValue
+	}
+
+	return value
+}
+
 func main() {
 	var metricsAddr string
 	var enableLeaderElection bool
 	var probeAddr string
+	var apiKeySecretNamespace string
+	var apiKeySecretName string
+	var apiKeySecretKey string
+	var apiBaseUrl string
+	var apiUserAgent string
+	var apiTimeout time.Duration
+	var apiProxyUrl string
+	var apiCABundleFile string
+	var apiMaxAttempts int
+	var snapshotMaxAge time.Duration
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
+	flag.StringVar(&apiKeySecretNamespace, "api-key-secret-namespace", os.Getenv("UPTIMEROBOT_API_KEY_SECRET_NAMESPACE"),
+		"The namespace of the Secret holding the UptimeRobot api key. "+
+			"Defaults to the UPTIMEROBOT_API_KEY_SECRET_NAMESPACE environment variable.")
+	flag.StringVar(&apiKeySecretName, "api-key-secret-name", os.Getenv("UPTIMEROBOT_API_KEY_SECRET_NAME"),
+		"The name of the Secret holding the UptimeRobot api key. "+
+			"Defaults to the UPTIMEROBOT_API_KEY_SECRET_NAME environment variable.")
+	flag.StringVar(&apiKeySecretKey, "api-key-secret-key", envOrDefault("UPTIMEROBOT_API_KEY_SECRET_KEY", "apiKey"),
+		"The key within the Secret holding the UptimeRobot api key. "+
+			"Defaults to the UPTIMEROBOT_API_KEY_SECRET_KEY environment variable or \"apiKey\".")
+	flag.StringVar(&apiBaseUrl, "uptimerobot-base-url", envOrDefault("UPTIMEROBOT_BASE_URL", uptimerobot.DefaultBaseUrl),
+		"The base url of the UptimeRobot api.")
+	flag.StringVar(&apiUserAgent, "uptimerobot-user-agent", envOrDefault("UPTIMEROBOT_USER_AGENT", uptimerobot.DefaultUserAgent),
+		"The User-Agent sent with UptimeRobot api requests.")
+	flag.DurationVar(&apiTimeout, "uptimerobot-timeout", uptimerobot.DefaultTimeout,
+		"The timeout for a single UptimeRobot api request.")
+	flag.IntVar(&apiMaxAttempts, "uptimerobot-max-attempts", uptimerobot.DefaultRetryPolicy.MaxAttempts,
+		"How many times an UptimeRobot api request is attempted before giving up on transient failures.")
+	flag.DurationVar(&snapshotMaxAge, "uptimerobot-snapshot-max-age", urrecon.DefaultSnapshotMaxAge,
+		"How long a snapshot of an account's monitors and alert contacts is reused before it is fetched again.")
+	flag.StringVar(&apiProxyUrl, "uptimerobot-proxy-url", os.Getenv("UPTIMEROBOT_PROXY_URL"),
+		"A proxy to send UptimeRobot api requests through. "+
+			"Defaults to the standard HTTPS_PROXY/NO_PROXY environment variables.")
+	flag.StringVar(&apiCABundleFile, "uptimerobot-ca-bundle", os.Getenv("UPTIMEROBOT_CA_BUNDLE"),
+		"Path to a PEM file of extra CA certificates to trust when calling the UptimeRobot api.")
 	opts := zap.Options{
 		Development: true,
 	}
@@ -68,46 +120,141 @@ func main() {
 
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
 
+	if apiKeySecretNamespace == "" || apiKeySecretName == "" || apiKeySecretKey == "" {
+		setupLog.Error(errors.New("no uptimerobot api key configured"),
+			"set --api-key-secret-namespace, --api-key-secret-name and --api-key-secret-key "+
+				"(or UPTIMEROBOT_API_KEY_SECRET_NAMESPACE, UPTIMEROBOT_API_KEY_SECRET_NAME and UPTIMEROBOT_API_KEY_SECRET_KEY)")
+		os.Exit(1)
+	}
+	apiKeySecret := types.NamespacedName{Namespace: apiKeySecretNamespace, Name: apiKeySecretName}
+
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
 		Scheme:                 scheme,
 		Metrics:                metricsserver.Options{BindAddress: metricsAddr},
 		HealthProbeBindAddress: probeAddr,
 		LeaderElection:         enableLeaderElection,
 		LeaderElectionID:       "265967f3.uptimerobot.com",
+		//the operator reads Secrets across namespaces, caching only the labelled ones keeps it from holding
+		//every Secret in the cluster in memory
+		Cache: cache.Options{
+			ByObject: map[client.Object]cache.ByObject{
+				&corev1.Secret{}: {
+					Label: labels.SelectorFromSet(labels.Set{uptimerobotcomv1alpha1.LABEL_SECRET: "true"}),
+				},
+			},
+		},
 	})
 	if err != nil {
 		setupLog.Error(err, "unable to start manager")
 		os.Exit(1)
 	}
 
-	uptimeRobotClient := uptimerobot.NewClient("u2441198-abf7abe866e6316b31c5c9d3")
+	// the manager cache isn't running yet so read the initial key straight from the api server
+	apiKeyCtx, cancelApiKeyCtx := context.WithTimeout(context.Background(), 30*time.Second)
+	apiKey, err := controller.GetApiKeyFromSecret(apiKeyCtx, mgr.GetAPIReader(), apiKeySecret, apiKeySecretKey)
+	cancelApiKeyCtx()
+	if err != nil {
+		setupLog.Error(err, "unable to load uptimerobot api key")
+		os.Exit(1)
+	}
+
+	retryPolicy := uptimerobot.DefaultRetryPolicy
+	retryPolicy.MaxAttempts = apiMaxAttempts
+	clientOptions := []uptimerobot.ClientOption{
+		uptimerobot.WithBaseUrl(apiBaseUrl),
+		uptimerobot.WithUserAgent(apiUserAgent),
+		uptimerobot.WithTimeout(apiTimeout),
+		uptimerobot.WithRetryPolicy(retryPolicy),
+	}
+	if apiProxyUrl != "" {
+		proxyUrl, err := url.Parse(apiProxyUrl)
+		if err != nil {
+			setupLog.Error(err, "invalid uptimerobot proxy url")
+			os.Exit(1)
+		}
+		clientOptions = append(clientOptions, uptimerobot.WithProxy(proxyUrl))
+	}
+	if apiCABundleFile != "" {
+		caBundle, err := os.ReadFile(apiCABundleFile)
+		if err != nil {
+			setupLog.Error(err, "unable to read uptimerobot ca bundle")
+			os.Exit(1)
+		}
+		clientOptions = append(clientOptions, uptimerobot.WithCABundle(caBundle))
+	}
+
+	uptimeRobotClient, err := uptimerobot.NewClient(apiKey, clientOptions...)
+	if err != nil {
+		setupLog.Error(err, "unable to create uptimerobot client")
+		os.Exit(1)
+	}
+
+	//the cache leaves out unlabelled Secrets, this client reports them as unlabelled rather than missing
+	kubeClient := controller.NewLabelCheckingClient(mgr.GetClient(), mgr.GetAPIReader())
+
+	if err = (&controller.ApiKeyReconciler{
+		Client:       kubeClient,
+		ApiKeySetter: uptimeRobotClient,
+		SecretName:   apiKeySecret,
+		SecretKey:    apiKeySecretKey,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ApiKey")
+		os.Exit(1)
+	}
+
+	uptimeRobotClients := uptimerobot.NewClientPool(uptimeRobotClient, clientOptions...)
+	snapshots := urrecon.NewSnapshotCaches(snapshotMaxAge)
 
 	if err = (&controller.AccountReconciler{
-		Client:               mgr.GetClient(),
-		Scheme:               mgr.GetScheme(),
-		AccountDetailsGetter: uptimeRobotClient,
+		Client:    kubeClient,
+		Scheme:    mgr.GetScheme(),
+		Clients:   uptimeRobotClients,
+		Snapshots: snapshots,
+		Recorder:  mgr.GetEventRecorderFor("account-controller"),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Account")
 		os.Exit(1)
 	}
 	if err = (&controller.AlertContactReconciler{
-		Client:                    mgr.GetClient(),
-		Scheme:                    mgr.GetScheme(),
-		AlertContactApiReconciler: urrecon.NewAlertContactApiReconciler(uptimeRobotClient),
-		AlertContactClient:        uptimeRobotClient,
+		Client:    kubeClient,
+		Scheme:    mgr.GetScheme(),
+		Clients:   uptimeRobotClients,
+		Snapshots: snapshots,
+		Recorder:  mgr.GetEventRecorderFor("alertcontact-controller"),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "AlertContact")
 		os.Exit(1)
 	}
 	if err = (&controller.MonitorReconciler{
-		Client:               mgr.GetClient(),
-		Scheme:               mgr.GetScheme(),
-		MonitorApiReconciler: urrecon.NewMonitorApiReconciler(uptimeRobotClient),
-		MonitorClient:        uptimeRobotClient,
+		Client:    kubeClient,
+		Scheme:    mgr.GetScheme(),
+		Clients:   uptimeRobotClients,
+		Snapshots: snapshots,
+		Recorder:  mgr.GetEventRecorderFor("monitor-controller"),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Monitor")
 		os.Exit(1)
 	}
+	if err = (&controller.MaintenanceWindowReconciler{
+		Client:    kubeClient,
+		Scheme:    mgr.GetScheme(),
+		Clients:   uptimeRobotClients,
+		Snapshots: snapshots,
+		Recorder:  mgr.GetEventRecorderFor("maintenancewindow-controller"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "MaintenanceWindow")
+		os.Exit(1)
+	}
+	if err = (&controller.StatusPageReconciler{
+		Client:    kubeClient,
+		Scheme:    mgr.GetScheme(),
+		Clients:   uptimeRobotClients,
+		Snapshots: snapshots,
+		Recorder:  mgr.GetEventRecorderFor("statuspage-controller"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "StatusPage")
+		os.Exit(1)
+	}
 	//+kubebuilder:scaffold:builder
 
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
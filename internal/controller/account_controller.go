@@ -20,21 +20,32 @@ import (
 	"context"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	uptimerobotcomv1alpha1 "github.com/luckielordie/uptime-robot-operator/api/v1alpha1"
+	"github.com/luckielordie/uptime-robot-operator/internal/controller/urrecon"
 	"github.com/luckielordie/uptime-robot-operator/internal/uptimerobot"
 )
 
 // AccountReconciler reconciles a Account object
 type AccountReconciler struct {
 	client.Client
-	AccountDetailsGetter uptimerobot.AccountDetailsGetter
-	Scheme               *runtime.Scheme
+	Scheme    *runtime.Scheme
+	Clients   *uptimerobot.ClientPool
+	Snapshots *urrecon.SnapshotCaches
+	Recorder  record.EventRecorder
 }
 
 func getAccount(ctx context.Context, reader client.Reader, req ctrl.Request) (uptimerobotcomv1alpha1.Account, error) {
@@ -53,34 +64,129 @@ func getAccount(ctx context.Context, reader client.Reader, req ctrl.Request) (up
 	return account, nil
 }
 
+// getAccountClient returns the uptimerobot client for an Account, reading its
+// api key from the referenced Secret so that key rotations are picked up
+func getAccountClient(ctx context.Context, reader client.Reader, clients *uptimerobot.ClientPool, account *uptimerobotcomv1alpha1.Account) (*uptimerobot.Client, error) {
+	secretRef := account.Spec.ApiKeySecretRef
+	if secretRef == nil {
+		return clients.Default(), nil
+	}
+
+	secretName := types.NamespacedName{Namespace: account.Namespace, Name: secretRef.Name}
+	apiKey, err := GetApiKeyFromSecret(ctx, reader, secretName, secretRef.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	return clients.ForAccount(client.ObjectKeyFromObject(account).String(), apiKey)
+}
+
+// getClientForAccountRef resolves the uptimerobot client for a resource's
+// accountRef, falling back to the default client when no Account is referenced
+func getClientForAccountRef(ctx context.Context, reader client.Reader, clients *uptimerobot.ClientPool, namespace string, accountRef *corev1.LocalObjectReference) (*uptimerobot.Client, error) {
+	account, err := getAccountForRef(ctx, reader, namespace, accountRef)
+	if err != nil {
+		return nil, err
+	}
+
+	if account == nil {
+		return clients.Default(), nil
+	}
+
+	return getAccountClient(ctx, reader, clients, account)
+}
+
+// getAccountForRef returns the Account a resource's accountRef names, or nil when it names none
+func getAccountForRef(ctx context.Context, reader client.Reader, namespace string, accountRef *corev1.LocalObjectReference) (*uptimerobotcomv1alpha1.Account, error) {
+	if accountRef == nil {
+		return nil, nil
+	}
+
+	account, err := getAccount(ctx, reader, ctrl.Request{
+		NamespacedName: types.NamespacedName{Namespace: namespace, Name: accountRef.Name},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &account, nil
+}
+
+// sameAccountRef tells whether two accountRefs in a namespace name the same Account, unset naming the
+// operator's default account
+func sameAccountRef(accountRef *corev1.LocalObjectReference, other *corev1.LocalObjectReference) bool {
+	if accountRef == nil || other == nil {
+		return accountRef == nil && other == nil
+	}
+
+	return accountRef.Name == other.Name
+}
+
 //+kubebuilder:rbac:groups=uptimerobot.com,resources=accounts,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=uptimerobot.com,resources=accounts/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=uptimerobot.com,resources=accounts/finalizers,verbs=update
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 
 func (reconciler *AccountReconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
 	account, err := getAccount(ctx, reconciler, request)
 	if err != nil {
+		if apierrors.IsNotFound(err) {
+			//the account's snapshot goes with its client, a new client would never read it again
+			if apiClient := reconciler.Clients.Remove(request.String()); apiClient != nil {
+				reconciler.Snapshots.Remove(apiClient)
+			}
+		}
+
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
+	originalStatus := account.Status.DeepCopy()
+
+	statusWriter := reconciler.Status()
+	apiClient, err := getAccountClient(ctx, reconciler, reconciler.Clients, &account)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			err = invalidConfig(err)
+		}
+
+		return recordSyncFailure(ctx, reconciler.Recorder, statusWriter, &account, &account.Status.Conditions, err)
+	}
 
 	//get sdk account
-	getAccountDetailsResponse, err := reconciler.AccountDetailsGetter.GetAccountDetails(ctx)
+	getAccountDetailsResponse, err := apiClient.GetAccountDetails(ctx)
 	if err != nil {
-		return ctrl.Result{}, err
+		if !uptimerobot.IsRateLimited(err) {
+			logger.Error(err, "failed to get account details", "reason", apiErrorReason(err))
+		}
+
+		return recordSyncFailure(ctx, reconciler.Recorder, statusWriter, &account, &account.Status.Conditions, err)
 	}
 
+	apiClient.SetRateLimit(uptimerobot.RateLimitForMonitorLimit(getAccountDetailsResponse.Account.MonitorLimit))
+
 	//update status
-	account.Status = uptimerobotcomv1alpha1.AccountStatus{
-		Email:           getAccountDetailsResponse.Account.Email,
-		MonitorLimit:    getAccountDetailsResponse.Account.MonitorLimit,
-		MonitorInterval: getAccountDetailsResponse.Account.MonitorInterval,
-		UpMonitors:      getAccountDetailsResponse.Account.UpMonitors,
-		DownMonitors:    getAccountDetailsResponse.Account.DownMonitors,
-		PausedMonitors:  getAccountDetailsResponse.Account.PausedMonitors,
+	account.Status.Email = getAccountDetailsResponse.Account.Email
+	account.Status.MonitorLimit = getAccountDetailsResponse.Account.MonitorLimit
+	account.Status.MonitorInterval = getAccountDetailsResponse.Account.MonitorInterval
+	account.Status.UpMonitors = getAccountDetailsResponse.Account.UpMonitors
+	account.Status.DownMonitors = getAccountDetailsResponse.Account.DownMonitors
+	account.Status.PausedMonitors = getAccountDetailsResponse.Account.PausedMonitors
+	account.Status.AlertThresholdsSupported = getAccountDetailsResponse.SupportsAlertThresholds()
+	if account.Status.LastSyncTime == nil {
+		reconciler.Recorder.Eventf(&account, corev1.EventTypeNormal, EVENT_ADOPTED,
+			"connected to UptimeRobot account %s", getAccountDetailsResponse.Account.Email)
+	}
+	account.Status.ObservedGeneration = account.Generation
+	setSyncSucceeded(&account.Status.Conditions, account.Generation, uptimerobotcomv1alpha1.REASON_IN_SYNC, "")
+	//the sync time only moves on when UptimeRobot reports something new
+	result := controllerutil.OperationResultUpdatedStatus
+	if !equality.Semantic.DeepEqual(originalStatus, &account.Status) {
+		result = controllerutil.OperationResultUpdated
 	}
+	account.Status.LastSyncTime = syncTime(account.Status.LastSyncTime, result)
 
-	statusWriter := reconciler.Status()
-	err = statusWriter.Update(ctx, &account)
+	err = updateStatusIfChanged(ctx, statusWriter, &account, originalStatus, &account.Status)
 	if err != nil {
 		return ctrl.Result{}, err
 	}
@@ -90,9 +196,30 @@ func (reconciler *AccountReconciler) Reconcile(ctx context.Context, request ctrl
 	}, nil
 }
 
+// accountsForSecret maps a Secret to the Accounts in its namespace that take their api key from it
+func (r *AccountReconciler) accountsForSecret(ctx context.Context, secret client.Object) []reconcile.Request {
+	logger := log.FromContext(ctx)
+	accounts := uptimerobotcomv1alpha1.AccountList{}
+	err := r.List(ctx, &accounts, client.InNamespace(secret.GetNamespace()))
+	if err != nil {
+		logger.Error(err, "failed to list accounts for secret", "secret", secret.GetName())
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, account := range accounts.Items {
+		if account.Spec.ApiKeySecretRef != nil && account.Spec.ApiKeySecretRef.Name == secret.GetName() {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&account)})
+		}
+	}
+
+	return requests
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *AccountReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&uptimerobotcomv1alpha1.Account{}).
+		For(&uptimerobotcomv1alpha1.Account{}, builder.WithPredicates(specChanged)).
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(r.accountsForSecret)).
 		Complete(r)
 }
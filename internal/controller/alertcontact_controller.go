@@ -19,34 +19,32 @@ package controller
 import (
 	"context"
 	"errors"
-	"strings"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	uptimerobotcomv1alpha1 "github.com/luckielordie/uptime-robot-operator/api/v1alpha1"
 	"github.com/luckielordie/uptime-robot-operator/internal/controller/urrecon"
 	"github.com/luckielordie/uptime-robot-operator/internal/uptimerobot"
 )
 
-type AlertContactAPIReconciler interface {
-	uptimerobot.AlertContactCreator
-	uptimerobot.AlertContactEditor
-	uptimerobot.AlertContactGetter
-	uptimerobot.AlertContactDeleter
-}
-
 // AlertContactReconciler reconciles a AlertContact object
 type AlertContactReconciler struct {
 	client.Client
-	urrecon.AlertContactApiReconciler
-	Scheme             *runtime.Scheme
-	AlertContactClient AlertContactAPIReconciler
+	Scheme    *runtime.Scheme
+	Clients   *uptimerobot.ClientPool
+	Snapshots *urrecon.SnapshotCaches
+	Recorder  record.EventRecorder
 }
 
 func getAlertContact(ctx context.Context, reader client.Reader, req ctrl.Request) (uptimerobotcomv1alpha1.AlertContact, error) {
@@ -148,6 +146,9 @@ const FINALIZER_TOKEN = "uptimerobot.com/finalizer"
 //+kubebuilder:rbac:groups=uptimerobot.com,resources=alertcontacts,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=uptimerobot.com,resources=alertcontacts/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=uptimerobot.com,resources=alertcontacts/finalizers,verbs=update
+//+kubebuilder:rbac:groups=uptimerobot.com,resources=accounts,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 
 func (reconciler *AlertContactReconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
 	logger := log.FromContext(ctx)
@@ -155,21 +156,51 @@ func (reconciler *AlertContactReconciler) Reconcile(ctx context.Context, request
 	if err != nil {
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
+	originalStatus := alertContact.Status.DeepCopy()
+
+	apiClient, err := getClientForAccountRef(ctx, reconciler, reconciler.Clients, alertContact.Namespace, alertContact.Spec.AccountRef)
+	if err != nil {
+		//a missing account can't come back to delete with, so a resource being deleted is let go
+		if apierrors.IsNotFound(err) && !alertContact.DeletionTimestamp.IsZero() {
+			return ctrl.Result{}, releaseFinalizer(ctx, reconciler.Client, reconciler.Recorder, &alertContact, FINALIZER_TOKEN, alertContact.Status.Id, err)
+		}
+
+		logger.Error(err, "failed to resolve uptimerobot account", "account", alertContact.Spec.AccountRef)
+		if apierrors.IsNotFound(err) {
+			err = invalidConfig(err)
+		}
+
+		return recordSyncFailure(ctx, reconciler.Recorder, reconciler.Client.Status(), &alertContact, &alertContact.Status.Conditions, err)
+	}
 
+	snapshot := reconciler.Snapshots.For(apiClient)
 	result, err := Finalize(ctx, reconciler.Client, &alertContact, FINALIZER_TOKEN, func(context.Context) error {
-		_, err := reconciler.AlertContactClient.DeleteAlertContact(ctx, alertContact.Status.Id)
+		//nothing was created on the api, so there is nothing to delete
+		if alertContact.Status.Id == "" {
+			return nil
+		}
+
+		_, err := apiClient.DeleteAlertContact(ctx, alertContact.Status.Id)
 		if err != nil {
-			errStr := err.Error()
-			if strings.Contains(errStr, "not_found") {
+			if uptimerobot.IsNotFound(err) {
 				return nil
 			}
-			logger.Error(err, "failed to delete alert contact", "id", alertContact.Status.Id)
+			if uptimerobot.IsRateLimited(err) {
+				return err
+			}
+			logger.Error(err, "failed to delete alert contact", "id", alertContact.Status.Id, "reason", apiErrorReason(err))
 			return err
 		}
 
+		snapshot.RemoveAlertContact(alertContact.Status.Id)
+		recordDeleteEvent(reconciler.Recorder, &alertContact, alertContact.Status.Id)
 		return nil
 	})
 	if err != nil || result != controllerutil.OperationResultNone {
+		if rateLimitedResult, ok := requeueIfRateLimited(ctx, err); ok {
+			return rateLimitedResult, nil
+		}
+
 		if result != controllerutil.OperationResultNone {
 			logger.Error(err, "failed finalizing alertcontact")
 		}
@@ -177,28 +208,54 @@ func (reconciler *AlertContactReconciler) Reconcile(ctx context.Context, request
 		return ctrl.Result{}, err
 	}
 
+	statusWriter := reconciler.Client.Status()
+	value, err := getValue(ctx, reconciler, alertContact.Namespace, uptimerobotcomv1alpha1.ValueOrSecret{
+		Value:     alertContact.Spec.Value,
+		ValueFrom: alertContact.Spec.ValueFrom,
+	})
+	if err != nil {
+		logger.Error(err, "failed to read alert contact value")
+		return recordSyncFailure(ctx, reconciler.Recorder, statusWriter, &alertContact, &alertContact.Status.Conditions, invalidConfig(err))
+	}
+
 	//CreateOrUpdate AlertContact
 	alertContactObj := urrecon.AlertContact{
 		Id: alertContact.Status.Id,
 	}
 
-	result, err = urrecon.ReconcileApiObject[urrecon.AlertContact](ctx, reconciler, &alertContactObj, func() error {
+	alertContactApiReconciler := urrecon.NewAlertContactApiReconciler(apiClient, snapshot)
+	result, err = urrecon.ReconcileApiObject[urrecon.AlertContact](ctx, &alertContactApiReconciler, &alertContactObj, func() error {
 		alertContactObj.Name = alertContact.Spec.Name
 		alertContactTypeId, err := AlertContactTypeToInt(alertContact.Spec.Type)
 		if err != nil {
-			return err
+			return invalidConfig(err)
 		}
 		alertContactObj.Type = alertContactTypeId
-		alertContactObj.Value = alertContact.Spec.Value
+		alertContactObj.Value = value
 		alertContactObj.Status = alertContact.Status.Status
 		return nil
 	})
 	if err != nil {
-		logger.Error(err, "failed updating alertcontact on api")
-		return ctrl.Result{}, err
+		if !uptimerobot.IsRateLimited(err) {
+			logger.Error(err, "failed updating alertcontact on api", "reason", apiErrorReason(err))
+		}
+
+		return recordSyncFailure(ctx, reconciler.Recorder, statusWriter, &alertContact, &alertContact.Status.Conditions, err)
 	}
 
 	if result != controllerutil.OperationResultNone {
+		missingId := ""
+		if result == controllerutil.OperationResultCreated {
+			missingId = alertContact.Status.Id
+		}
+		event := syncEvent{
+			result:    result,
+			drifted:   alertContact.Status.ObservedGeneration == alertContact.Generation && alertContact.Status.ValueHash == alertContactObj.ValueHash(),
+			adopted:   alertContact.Status.LastSyncTime == nil && missingId == "" && result != controllerutil.OperationResultCreated,
+			missingId: missingId,
+			id:        alertContactObj.Id,
+		}
+
 		alertContact.Status.Id = alertContactObj.Id
 		alertContact.Status.Status = alertContactObj.Status
 		alertContact.Status.Name = alertContactObj.Name
@@ -208,10 +265,16 @@ func (reconciler *AlertContactReconciler) Reconcile(ctx context.Context, request
 			return ctrl.Result{}, err
 		}
 		alertContact.Status.Type = alertContactType
-		alertContact.Status.Value = alertContactObj.Value
+		alertContact.Status.ValueHash = alertContactObj.ValueHash()
+
+		alertContact.Status.ObservedGeneration = alertContact.Generation
+		alertContact.Status.LastSyncTime = syncTime(alertContact.Status.LastSyncTime, result)
+		setSyncSucceeded(&alertContact.Status.Conditions, alertContact.Generation, resultReason(result), "")
+		setRemoteMissing(&alertContact.Status.Conditions, alertContact.Generation, missingId, alertContactObj.Id)
 
-		statusClient := reconciler.Client.Status()
-		err = statusClient.Update(ctx, &alertContact)
+		recordSyncEvent(reconciler.Recorder, &alertContact, event)
+
+		err = updateStatusIfChanged(ctx, statusWriter, &alertContact, originalStatus, &alertContact.Status)
 		if err != nil {
 			logger.Error(err, "failed updating status")
 			return ctrl.Result{}, err
@@ -223,9 +286,31 @@ func (reconciler *AlertContactReconciler) Reconcile(ctx context.Context, request
 	}, nil
 }
 
+// alertContactsForSecret maps a Secret to the AlertContacts in its namespace that read their value from it
+func (r *AlertContactReconciler) alertContactsForSecret(ctx context.Context, secret client.Object) []reconcile.Request {
+	logger := log.FromContext(ctx)
+	alertContacts := uptimerobotcomv1alpha1.AlertContactList{}
+	err := r.List(ctx, &alertContacts, client.InNamespace(secret.GetNamespace()))
+	if err != nil {
+		logger.Error(err, "failed to list alert contacts for secret", "secret", secret.GetName())
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, alertContact := range alertContacts.Items {
+		valueFrom := alertContact.Spec.ValueFrom
+		if valueFrom != nil && valueFrom.SecretKeyRef.Name == secret.GetName() {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&alertContact)})
+		}
+	}
+
+	return requests
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *AlertContactReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&uptimerobotcomv1alpha1.AlertContact{}).
+		For(&uptimerobotcomv1alpha1.AlertContact{}, builder.WithPredicates(specChanged)).
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(r.alertContactsForSecret)).
 		Complete(r)
 }
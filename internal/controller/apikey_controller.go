@@ -0,0 +1,111 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	uptimerobotcomv1alpha1 "github.com/luckielordie/uptime-robot-operator/api/v1alpha1"
+	"github.com/luckielordie/uptime-robot-operator/internal/uptimerobot"
+)
+
+// GetApiKeyFromSecret reads an uptimerobot api key out of the given key of a Secret
+func GetApiKeyFromSecret(ctx context.Context, reader client.Reader, secretName types.NamespacedName, secretKey string) (string, error) {
+	secret := corev1.Secret{}
+	err := reader.Get(ctx, secretName, &secret)
+	if err != nil {
+		return "", fmt.Errorf("failed to get api key secret %s: %w", secretName, err)
+	}
+
+	//read from the api server the key is found either way, but only a labelled Secret is watched for rotations
+	if secret.Labels[uptimerobotcomv1alpha1.LABEL_SECRET] != "true" {
+		log.FromContext(ctx).Info("api key secret isn't labelled for the operator, rotating the key won't be picked up until it is",
+			"secret", secretName, "label", uptimerobotcomv1alpha1.LABEL_SECRET)
+	}
+
+	apiKey, ok := secret.Data[secretKey]
+	if !ok {
+		return "", fmt.Errorf("api key secret %s has no key %q", secretName, secretKey)
+	}
+
+	if len(apiKey) == 0 {
+		return "", fmt.Errorf("api key secret %s has an empty value for key %q", secretName, secretKey)
+	}
+
+	return string(apiKey), nil
+}
+
+// ApiKeyReconciler watches the Secret holding the uptimerobot api key and
+// swaps the key on the shared client whenever the Secret changes
+type ApiKeyReconciler struct {
+	client.Client
+	ApiKeySetter uptimerobot.ApiKeySetter
+	SecretName   types.NamespacedName
+	SecretKey    string
+}
+
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+
+func (reconciler *ApiKeyReconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+	if request.NamespacedName != reconciler.SecretName {
+		return ctrl.Result{}, nil
+	}
+
+	apiKey, err := GetApiKeyFromSecret(ctx, reconciler, reconciler.SecretName, reconciler.SecretKey)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.Info("api key secret removed, keeping the current api key", "secret", reconciler.SecretName)
+			return ctrl.Result{}, nil
+		}
+		if isInvalidConfig(err) {
+			logger.Info("api key secret is no longer labelled for the operator, keeping the current api key", "secret", reconciler.SecretName,
+				"label", uptimerobotcomv1alpha1.LABEL_SECRET)
+			return ctrl.Result{}, nil
+		}
+
+		logger.Error(err, "failed to read api key, keeping the current api key")
+		return ctrl.Result{}, err
+	}
+
+	reconciler.ApiKeySetter.SetApiKey(apiKey)
+	logger.Info("api key updated from secret", "secret", reconciler.SecretName)
+
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ApiKeyReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	isApiKeySecret := predicate.NewPredicateFuncs(func(object client.Object) bool {
+		return object.GetNamespace() == r.SecretName.Namespace && object.GetName() == r.SecretName.Name
+	})
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("apikey").
+		For(&corev1.Secret{}, builder.WithPredicates(isApiKeySecret)).
+		Complete(r)
+}
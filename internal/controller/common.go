@@ -2,11 +2,111 @@ package controller
 
 import (
 	"context"
+	"fmt"
+	"reflect"
 
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+
+	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	uptimerobotcomv1alpha1 "github.com/luckielordie/uptime-robot-operator/api/v1alpha1"
+	"github.com/luckielordie/uptime-robot-operator/internal/uptimerobot"
 )
 
+// labelCheckingClient reads through the manager's cache, which only holds Secrets labelled for the operator,
+// and asks the api server about the Secrets missing from it so that a missing label is reported as such
+type labelCheckingClient struct {
+	client.Client
+	apiReader client.Reader
+}
+
+// NewLabelCheckingClient wraps the manager's client, apiReader being the manager's uncached reader
+func NewLabelCheckingClient(cachedClient client.Client, apiReader client.Reader) client.Client {
+	return &labelCheckingClient{Client: cachedClient, apiReader: apiReader}
+}
+
+func (labelCheckingClient *labelCheckingClient) Get(ctx context.Context, key client.ObjectKey, object client.Object, opts ...client.GetOption) error {
+	err := labelCheckingClient.Client.Get(ctx, key, object, opts...)
+	if _, ok := object.(*corev1.Secret); !ok || !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	//only the metadata is needed to tell the Secret exists, so its data isn't fetched
+	secretMetadata := metav1.PartialObjectMetadata{}
+	secretMetadata.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("Secret"))
+	if apiErr := labelCheckingClient.apiReader.Get(ctx, key, &secretMetadata); apiErr != nil {
+		return err
+	}
+
+	log.FromContext(ctx).Info("secret isn't labelled for the operator", "secret", key, "label", uptimerobotcomv1alpha1.LABEL_SECRET)
+	return invalidConfig(fmt.Errorf("secret %s exists but isn't labelled %s=true, the operator only reads Secrets labelled so",
+		key, uptimerobotcomv1alpha1.LABEL_SECRET))
+}
+
+// getSecretKeyValue reads the value of a key of a Secret in the given namespace. Errors name
+// the Secret and key but never include the value.
+func getSecretKeyValue(ctx context.Context, reader client.Reader, namespace string, selector corev1.SecretKeySelector) (string, error) {
+	secretName := types.NamespacedName{Namespace: namespace, Name: selector.Name}
+	secret := corev1.Secret{}
+	err := reader.Get(ctx, secretName, &secret)
+	if err != nil {
+		return "", fmt.Errorf("failed to get secret %s: %w", secretName, err)
+	}
+
+	value, ok := secret.Data[selector.Key]
+	if !ok {
+		return "", fmt.Errorf("secret %s has no key %q", secretName, selector.Key)
+	}
+
+	return string(value), nil
+}
+
+// getValue returns a value given inline or read from a Secret in the given namespace
+func getValue(ctx context.Context, reader client.Reader, namespace string, value uptimerobotcomv1alpha1.ValueOrSecret) (string, error) {
+	if value.ValueFrom == nil {
+		return value.Value, nil
+	}
+
+	return getSecretKeyValue(ctx, reader, namespace, value.ValueFrom.SecretKeyRef)
+}
+
+// apiErrorReason classifies an uptimerobot api error for logging
+func apiErrorReason(err error) string {
+	switch {
+	case uptimerobot.IsUnauthorized(err):
+		return "api key rejected"
+	case uptimerobot.IsRateLimited(err):
+		return "rate limited"
+	case uptimerobot.IsInvalidParameter(err):
+		return "invalid parameter"
+	case uptimerobot.IsNotFound(err):
+		return "not found"
+	default:
+		return "unknown"
+	}
+}
+
+// requeueIfRateLimited turns a throttled api request into a delayed requeue so that
+// hitting the account's rate limit doesn't surface as a storm of reconcile errors
+func requeueIfRateLimited(ctx context.Context, err error) (ctrl.Result, bool) {
+	retryAfter, ok := uptimerobot.RetryAfter(err)
+	if !ok {
+		return ctrl.Result{}, false
+	}
+
+	log.FromContext(ctx).Info("uptimerobot api rate limit reached, requeueing", "after", retryAfter)
+	return ctrl.Result{RequeueAfter: retryAfter}, true
+}
+
 func Finalize(ctx context.Context, reconciler client.Client, object client.Object, finalizerString string, finaliser func(context.Context) error) (controllerutil.OperationResult, error) {
 	isMarkedToBeDeleted := object.GetDeletionTimestamp() != nil
 	if isMarkedToBeDeleted {
@@ -38,3 +138,41 @@ func Finalize(ctx context.Context, reconciler client.Client, object client.Objec
 
 	return controllerutil.OperationResultNone, nil
 }
+
+// releaseFinalizer lets go of a resource that is being deleted while the account it was synced with can't be
+// resolved anymore, there is no api key left to delete its object from UptimeRobot with so the object stays
+func releaseFinalizer(ctx context.Context, reconciler client.Client, recorder record.EventRecorder, object client.Object, finalizerString string, id string, err error) error {
+	if !controllerutil.ContainsFinalizer(object, finalizerString) {
+		return nil
+	}
+
+	log.FromContext(ctx).Info("removing finalizer without deleting from UptimeRobot", "id", id, "reason", err.Error())
+	if id != "" {
+		recordOrphanEvent(recorder, object, id, err)
+	}
+
+	controllerutil.RemoveFinalizer(object, finalizerString)
+	return reconciler.Update(ctx, object)
+}
+
+// specChanged skips the updates a resource gets from its own status writes, leaving spec, label and annotation
+// changes, the paused annotation among them, to reconcile it
+var specChanged = predicate.Or(
+	predicate.GenerationChangedPredicate{},
+	predicate.LabelChangedPredicate{},
+	predicate.AnnotationChangedPredicate{},
+)
+
+// resourceChanged lets through the updates of a watched resource that change its spec or the part of its
+// status, picked by key, that the watching resources are synced from
+func resourceChanged(key func(object client.Object) interface{}) predicate.Predicate {
+	return predicate.Or(specChanged, predicate.Funcs{
+		UpdateFunc: func(updateEvent event.UpdateEvent) bool {
+			if updateEvent.ObjectOld == nil || updateEvent.ObjectNew == nil {
+				return false
+			}
+
+			return !reflect.DeepEqual(key(updateEvent.ObjectOld), key(updateEvent.ObjectNew))
+		},
+	})
+}
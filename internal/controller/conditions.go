@@ -0,0 +1,181 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	uptimerobotcomv1alpha1 "github.com/luckielordie/uptime-robot-operator/api/v1alpha1"
+	"github.com/luckielordie/uptime-robot-operator/internal/uptimerobot"
+)
+
+// configError marks a failure caused by a resource's spec, or the objects it references, rather than the api
+type configError struct {
+	err error
+}
+
+func (err *configError) Error() string {
+	return err.err.Error()
+}
+
+func (err *configError) Unwrap() error {
+	return err.err
+}
+
+func invalidConfig(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return &configError{err: err}
+}
+
+// isInvalidConfig is true for errors the user has to fix in the resource, including requests
+// built from it that the api rejected
+func isInvalidConfig(err error) bool {
+	var configError *configError
+	return errors.As(err, &configError) || uptimerobot.IsInvalidParameter(err)
+}
+
+// failureReason is the condition reason for a failed sync
+func failureReason(err error) string {
+	switch {
+	case uptimerobot.IsRateLimited(err):
+		return uptimerobotcomv1alpha1.REASON_RATE_LIMITED
+	case uptimerobot.IsUnauthorized(err):
+		return uptimerobotcomv1alpha1.REASON_UNAUTHORIZED
+	case isInvalidConfig(err):
+		return uptimerobotcomv1alpha1.REASON_INVALID_CONFIG
+	default:
+		return uptimerobotcomv1alpha1.REASON_API_ERROR
+	}
+}
+
+// resultReason is the condition reason for a successful sync
+func resultReason(result controllerutil.OperationResult) string {
+	switch result {
+	case controllerutil.OperationResultCreated:
+		return uptimerobotcomv1alpha1.REASON_CREATED
+	case controllerutil.OperationResultUpdated:
+		return uptimerobotcomv1alpha1.REASON_UPDATED
+	default:
+		return uptimerobotcomv1alpha1.REASON_IN_SYNC
+	}
+}
+
+// syncTime is when the resource was last synced. It only moves on when the sync created or edited the
+// object on UptimeRobot, or on the first sync, so that an in-sync reconcile leaves the status unchanged.
+func syncTime(lastSyncTime *metav1.Time, result controllerutil.OperationResult) *metav1.Time {
+	if lastSyncTime != nil && result != controllerutil.OperationResultCreated && result != controllerutil.OperationResultUpdated {
+		return lastSyncTime
+	}
+
+	now := metav1.Now()
+	return &now
+}
+
+// updateStatusIfChanged writes the resource's status unless it still equals original, the status it was
+// read with. Every write is a watch event that reconciles the resource, and those watching it, again.
+func updateStatusIfChanged(ctx context.Context, statusWriter client.StatusWriter, object client.Object, original interface{}, status interface{}) error {
+	if equality.Semantic.DeepEqual(original, status) {
+		return nil
+	}
+
+	return statusWriter.Update(ctx, object)
+}
+
+// setSyncSucceeded marks the resource ready and synced. Degraded is cleared, callers that know
+// better set it again afterwards.
+func setSyncSucceeded(conditions *[]metav1.Condition, generation int64, reason string, message string) {
+	for _, conditionType := range []string{uptimerobotcomv1alpha1.CONDITION_READY, uptimerobotcomv1alpha1.CONDITION_SYNCED} {
+		meta.SetStatusCondition(conditions, metav1.Condition{
+			Type:               conditionType,
+			Status:             metav1.ConditionTrue,
+			Reason:             reason,
+			Message:            message,
+			ObservedGeneration: generation,
+		})
+	}
+
+	meta.SetStatusCondition(conditions, metav1.Condition{
+		Type:               uptimerobotcomv1alpha1.CONDITION_DEGRADED,
+		Status:             metav1.ConditionFalse,
+		Reason:             uptimerobotcomv1alpha1.REASON_AS_EXPECTED,
+		ObservedGeneration: generation,
+	})
+}
+
+// setSyncFailed marks the last sync as failed. A resource that was ready before keeps working
+// on UptimeRobot as last synced, so it stays ready but degraded.
+func setSyncFailed(conditions *[]metav1.Condition, generation int64, err error) {
+	reason := failureReason(err)
+	meta.SetStatusCondition(conditions, metav1.Condition{
+		Type:               uptimerobotcomv1alpha1.CONDITION_SYNCED,
+		Status:             metav1.ConditionFalse,
+		Reason:             reason,
+		Message:            err.Error(),
+		ObservedGeneration: generation,
+	})
+
+	if meta.IsStatusConditionTrue(*conditions, uptimerobotcomv1alpha1.CONDITION_READY) {
+		meta.SetStatusCondition(conditions, metav1.Condition{
+			Type:               uptimerobotcomv1alpha1.CONDITION_DEGRADED,
+			Status:             metav1.ConditionTrue,
+			Reason:             reason,
+			Message:            fmt.Sprintf("UptimeRobot keeps the last synced state: %s", err),
+			ObservedGeneration: generation,
+		})
+		return
+	}
+
+	meta.SetStatusCondition(conditions, metav1.Condition{
+		Type:               uptimerobotcomv1alpha1.CONDITION_READY,
+		Status:             metav1.ConditionFalse,
+		Reason:             reason,
+		Message:            err.Error(),
+		ObservedGeneration: generation,
+	})
+}
+
+// setRemoteMissing records whether the object had to be recreated because it was removed from
+// UptimeRobot, missingId is the id it had before and is empty when nothing went missing
+func setRemoteMissing(conditions *[]metav1.Condition, generation int64, missingId string, id string) {
+	condition := metav1.Condition{
+		Type:               uptimerobotcomv1alpha1.CONDITION_REMOTE_MISSING,
+		Status:             metav1.ConditionFalse,
+		Reason:             uptimerobotcomv1alpha1.REASON_PRESENT,
+		ObservedGeneration: generation,
+	}
+	if missingId != "" {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = uptimerobotcomv1alpha1.REASON_RECREATED
+		condition.Message = fmt.Sprintf("id %s was missing on UptimeRobot and has been recreated as id %s", missingId, id)
+	}
+
+	meta.SetStatusCondition(conditions, condition)
+}
+
+// recordSyncFailure stores a failed sync in the resource's conditions and events and hands the error back to
+// the caller, delaying the requeue instead when the api rate limited the request
+func recordSyncFailure(ctx context.Context, recorder record.EventRecorder, statusWriter client.StatusWriter, object client.Object, conditions *[]metav1.Condition, err error) (ctrl.Result, error) {
+	recordFailureEvent(recorder, object, err)
+	setSyncFailed(conditions, object.GetGeneration(), err)
+	if statusErr := statusWriter.Update(ctx, object); statusErr != nil {
+		log.FromContext(ctx).Error(statusErr, "failed updating status")
+	}
+
+	if rateLimitedResult, ok := requeueIfRateLimited(ctx, err); ok {
+		return rateLimitedResult, nil
+	}
+
+	return ctrl.Result{}, err
+}
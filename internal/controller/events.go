@@ -0,0 +1,67 @@
+package controller
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// Event reasons, warnings about failed syncs use the condition reasons
+const (
+	EVENT_CREATED         = "Created"
+	EVENT_UPDATED         = "Updated"
+	EVENT_DELETED         = "Deleted"
+	EVENT_ADOPTED         = "Adopted"
+	EVENT_DRIFT_CORRECTED = "DriftCorrected"
+	EVENT_REMOTE_MISSING  = "RemoteMissing"
+	EVENT_ORPHANED        = "Orphaned"
+)
+
+// syncEvent describes a successful sync of a resource with UptimeRobot
+type syncEvent struct {
+	result controllerutil.OperationResult
+	// drifted is true when an update was needed although neither the spec nor the Secrets it
+	// references changed since the last sync
+	drifted bool
+	// adopted is true the first time the operator syncs an object that already exists on UptimeRobot
+	adopted bool
+	// missingId is the id of an object that was removed from UptimeRobot and recreated
+	missingId string
+	id        string
+}
+
+// recordSyncEvent tells the story of a successful sync in the resource's events
+func recordSyncEvent(recorder record.EventRecorder, object client.Object, event syncEvent) {
+	switch {
+	case event.result == controllerutil.OperationResultCreated && event.missingId != "":
+		recorder.Eventf(object, corev1.EventTypeWarning, EVENT_REMOTE_MISSING,
+			"id %s was missing on UptimeRobot and has been recreated as id %s", event.missingId, event.id)
+	case event.result == controllerutil.OperationResultCreated:
+		recorder.Eventf(object, corev1.EventTypeNormal, EVENT_CREATED, "created on UptimeRobot with id %s", event.id)
+	case event.result == controllerutil.OperationResultUpdated && event.drifted:
+		recorder.Eventf(object, corev1.EventTypeWarning, EVENT_DRIFT_CORRECTED,
+			"id %s was changed on UptimeRobot outside of the operator and has been reset to the spec", event.id)
+	case event.result == controllerutil.OperationResultUpdated:
+		recorder.Eventf(object, corev1.EventTypeNormal, EVENT_UPDATED, "updated id %s on UptimeRobot", event.id)
+	case event.adopted:
+		recorder.Eventf(object, corev1.EventTypeNormal, EVENT_ADOPTED, "adopted id %s on UptimeRobot", event.id)
+	}
+}
+
+// recordDeleteEvent records the removal of a resource's object from UptimeRobot
+func recordDeleteEvent(recorder record.EventRecorder, object client.Object, id string) {
+	recorder.Event(object, corev1.EventTypeNormal, EVENT_DELETED, fmt.Sprintf("deleted id %s from UptimeRobot", id))
+}
+
+// recordOrphanEvent warns that a deleted resource's object was left on UptimeRobot
+func recordOrphanEvent(recorder record.EventRecorder, object client.Object, id string, err error) {
+	recorder.Event(object, corev1.EventTypeWarning, EVENT_ORPHANED, fmt.Sprintf("left id %s on UptimeRobot: %s", id, err))
+}
+
+// recordFailureEvent warns about a failed sync
+func recordFailureEvent(recorder record.EventRecorder, object client.Object, err error) {
+	recorder.Event(object, corev1.EventTypeWarning, failureReason(err), err.Error())
+}
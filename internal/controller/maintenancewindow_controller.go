@@ -0,0 +1,283 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	uptimerobotcomv1alpha1 "github.com/luckielordie/uptime-robot-operator/api/v1alpha1"
+	"github.com/luckielordie/uptime-robot-operator/internal/controller/urrecon"
+	"github.com/luckielordie/uptime-robot-operator/internal/uptimerobot"
+)
+
+// MaintenanceWindowReconciler reconciles a MaintenanceWindow object
+type MaintenanceWindowReconciler struct {
+	client.Client
+	Scheme    *runtime.Scheme
+	Clients   *uptimerobot.ClientPool
+	Snapshots *urrecon.SnapshotCaches
+	Recorder  record.EventRecorder
+}
+
+func getMaintenanceWindow(ctx context.Context, reader client.Reader, req ctrl.Request) (uptimerobotcomv1alpha1.MaintenanceWindow, error) {
+	logger := log.FromContext(ctx)
+	mwindow := uptimerobotcomv1alpha1.MaintenanceWindow{}
+	err := reader.Get(ctx, req.NamespacedName, &mwindow)
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			logger.Error(err, "failed to retrieve maintenance window resource")
+		}
+
+		logger.Info("requeue", "reason", "failed to get")
+		return uptimerobotcomv1alpha1.MaintenanceWindow{}, err
+	}
+
+	return mwindow, nil
+}
+
+func MaintenanceWindowTypeToInt(mwindowType uptimerobotcomv1alpha1.MaintenanceWindowType) (int, error) {
+	switch mwindowType {
+	case uptimerobotcomv1alpha1.MAINTENANCE_ONCE:
+		return uptimerobot.MWindowTypeOnce, nil
+	case uptimerobotcomv1alpha1.MAINTENANCE_DAILY:
+		return uptimerobot.MWindowTypeDaily, nil
+	case uptimerobotcomv1alpha1.MAINTENANCE_WEEKLY:
+		return uptimerobot.MWindowTypeWeekly, nil
+	case uptimerobotcomv1alpha1.MAINTENANCE_MONTHLY:
+		return uptimerobot.MWindowTypeMonthly, nil
+	default:
+		return 0, errors.New("unrecognised maintenance window type")
+	}
+}
+
+func IntToMaintenanceWindowType(mwindowTypeId int) (uptimerobotcomv1alpha1.MaintenanceWindowType, error) {
+	switch mwindowTypeId {
+	case uptimerobot.MWindowTypeOnce:
+		return uptimerobotcomv1alpha1.MAINTENANCE_ONCE, nil
+	case uptimerobot.MWindowTypeDaily:
+		return uptimerobotcomv1alpha1.MAINTENANCE_DAILY, nil
+	case uptimerobot.MWindowTypeWeekly:
+		return uptimerobotcomv1alpha1.MAINTENANCE_WEEKLY, nil
+	case uptimerobot.MWindowTypeMonthly:
+		return uptimerobotcomv1alpha1.MAINTENANCE_MONTHLY, nil
+	default:
+		return "", errors.New("unrecognised maintenance window type")
+	}
+}
+
+// setMaintenanceWindowSchedule fills in when the api window happens from the spec. Once windows start at
+// a unix timestamp, the others at HH:mm, in the account's timezone, on the days listed in value.
+func setMaintenanceWindowSchedule(mwindowObj *urrecon.MaintenanceWindow, spec uptimerobotcomv1alpha1.MaintenanceWindowSpec) error {
+	mwindowType, err := MaintenanceWindowTypeToInt(spec.Type)
+	if err != nil {
+		return err
+	}
+	mwindowObj.Type = mwindowType
+	mwindowObj.Duration = spec.Duration
+
+	//the api runs recurring windows in the account's timezone, there is no timezone to convert them from
+	if spec.Type != uptimerobotcomv1alpha1.MAINTENANCE_ONCE && spec.Timezone != "" {
+		return errors.New("timezone can only be set for once windows")
+	}
+
+	timezone := spec.Timezone
+	if timezone == "" {
+		timezone = "UTC"
+	}
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		return fmt.Errorf("unknown timezone %q: %w", timezone, err)
+	}
+
+	mwindowObj.Start = spec.Start
+	if spec.Type == uptimerobotcomv1alpha1.MAINTENANCE_ONCE {
+		start, err := time.ParseInLocation("2006-01-02 15:04", spec.Date+" "+spec.Start, location)
+		if err != nil {
+			return fmt.Errorf("invalid date and start: %w", err)
+		}
+		mwindowObj.Start = strconv.FormatInt(start.Unix(), 10)
+	}
+
+	days := make([]int, len(spec.Days))
+	for i, day := range spec.Days {
+		days[i] = int(day)
+	}
+	sort.Ints(days)
+
+	values := make([]string, len(days))
+	for i, day := range days {
+		values[i] = strconv.Itoa(day)
+	}
+	mwindowObj.Value = strings.Join(values, "-")
+
+	return nil
+}
+
+//+kubebuilder:rbac:groups=uptimerobot.com,resources=maintenancewindows,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=uptimerobot.com,resources=maintenancewindows/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=uptimerobot.com,resources=maintenancewindows/finalizers,verbs=update
+//+kubebuilder:rbac:groups=uptimerobot.com,resources=accounts,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+
+func (reconciler *MaintenanceWindowReconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+	mwindow, err := getMaintenanceWindow(ctx, reconciler, request)
+	if err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	originalStatus := mwindow.Status.DeepCopy()
+
+	apiClient, err := getClientForAccountRef(ctx, reconciler, reconciler.Clients, mwindow.Namespace, mwindow.Spec.AccountRef)
+	if err != nil {
+		//a missing account can't come back to delete with, so a resource being deleted is let go
+		if apierrors.IsNotFound(err) && !mwindow.DeletionTimestamp.IsZero() {
+			return ctrl.Result{}, releaseFinalizer(ctx, reconciler.Client, reconciler.Recorder, &mwindow, FINALIZER_TOKEN, mwindow.Status.Id, err)
+		}
+
+		logger.Error(err, "failed to resolve uptimerobot account", "account", mwindow.Spec.AccountRef)
+		if apierrors.IsNotFound(err) {
+			err = invalidConfig(err)
+		}
+
+		return recordSyncFailure(ctx, reconciler.Recorder, reconciler.Client.Status(), &mwindow, &mwindow.Status.Conditions, err)
+	}
+
+	snapshot := reconciler.Snapshots.For(apiClient)
+	result, err := Finalize(ctx, reconciler.Client, &mwindow, FINALIZER_TOKEN, func(context.Context) error {
+		//nothing was created on the api, so there is nothing to delete
+		if mwindow.Status.Id == "" {
+			return nil
+		}
+
+		_, err := apiClient.DeleteMWindow(ctx, mwindow.Status.Id)
+		if err != nil {
+			if uptimerobot.IsNotFound(err) {
+				return nil
+			}
+			if uptimerobot.IsRateLimited(err) {
+				return err
+			}
+			logger.Error(err, "failed to delete maintenance window", "id", mwindow.Status.Id, "reason", apiErrorReason(err))
+			return err
+		}
+
+		snapshot.RemoveMaintenanceWindow(mwindow.Status.Id)
+		recordDeleteEvent(reconciler.Recorder, &mwindow, mwindow.Status.Id)
+		return nil
+	})
+	if err != nil || result != controllerutil.OperationResultNone {
+		if rateLimitedResult, ok := requeueIfRateLimited(ctx, err); ok {
+			return rateLimitedResult, nil
+		}
+
+		if result != controllerutil.OperationResultNone {
+			logger.Error(err, "failed finalizing maintenance window")
+		}
+
+		return ctrl.Result{}, err
+	}
+
+	statusWriter := reconciler.Client.Status()
+	mwindowObj := urrecon.MaintenanceWindow{
+		Id: mwindow.Status.Id,
+	}
+
+	mwindowApiReconciler := urrecon.NewMaintenanceWindowApiReconciler(apiClient, snapshot)
+	result, err = urrecon.ReconcileApiObject[urrecon.MaintenanceWindow](ctx, &mwindowApiReconciler, &mwindowObj, func() error {
+		mwindowObj.Name = mwindow.Spec.Name
+		return invalidConfig(setMaintenanceWindowSchedule(&mwindowObj, mwindow.Spec))
+	})
+	if err != nil {
+		if !uptimerobot.IsRateLimited(err) {
+			logger.Error(err, "failed updating maintenance window on api", "reason", apiErrorReason(err))
+		}
+
+		return recordSyncFailure(ctx, reconciler.Recorder, statusWriter, &mwindow, &mwindow.Status.Conditions, err)
+	}
+
+	if result != controllerutil.OperationResultNone {
+		missingId := ""
+		if result == controllerutil.OperationResultCreated {
+			missingId = mwindow.Status.Id
+		}
+		event := syncEvent{
+			result:    result,
+			drifted:   mwindow.Status.ObservedGeneration == mwindow.Generation,
+			adopted:   mwindow.Status.LastSyncTime == nil && missingId == "" && result != controllerutil.OperationResultCreated,
+			missingId: missingId,
+			id:        mwindowObj.Id,
+		}
+
+		mwindow.Status.Id = mwindowObj.Id
+		mwindow.Status.Name = mwindowObj.Name
+		mwindowType, err := IntToMaintenanceWindowType(mwindowObj.Type)
+		if err != nil {
+			logger.Error(err, "failed parsing maintenance window type")
+			return ctrl.Result{}, err
+		}
+		mwindow.Status.Type = mwindowType
+
+		//whether the window is active is only known to the api
+		mwindows, err := snapshot.MaintenanceWindows(ctx)
+		if err != nil {
+			if rateLimitedResult, ok := requeueIfRateLimited(ctx, err); ok {
+				return rateLimitedResult, nil
+			}
+			return ctrl.Result{}, err
+		}
+		mwindow.Status.Status = mwindows[mwindowObj.Id].Status
+
+		mwindow.Status.ObservedGeneration = mwindow.Generation
+		mwindow.Status.LastSyncTime = syncTime(mwindow.Status.LastSyncTime, result)
+		setSyncSucceeded(&mwindow.Status.Conditions, mwindow.Generation, resultReason(result), "")
+		setRemoteMissing(&mwindow.Status.Conditions, mwindow.Generation, missingId, mwindowObj.Id)
+
+		recordSyncEvent(reconciler.Recorder, &mwindow, event)
+
+		err = updateStatusIfChanged(ctx, statusWriter, &mwindow, originalStatus, &mwindow.Status)
+		if err != nil {
+			logger.Error(err, "failed updating status")
+			return ctrl.Result{}, err
+		}
+	}
+
+	return ctrl.Result{
+		RequeueAfter: time.Second * 15,
+	}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *MaintenanceWindowReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&uptimerobotcomv1alpha1.MaintenanceWindow{}, builder.WithPredicates(specChanged)).
+		Complete(r)
+}
@@ -0,0 +1,84 @@
+package controller
+
+import (
+	"testing"
+	_ "time/tzdata"
+
+	uptimerobotcomv1alpha1 "github.com/luckielordie/uptime-robot-operator/api/v1alpha1"
+	"github.com/luckielordie/uptime-robot-operator/internal/controller/urrecon"
+	"github.com/luckielordie/uptime-robot-operator/internal/uptimerobot"
+)
+
+func TestSetMaintenanceWindowSchedule(t *testing.T) {
+	testCases := []struct {
+		name     string
+		spec     uptimerobotcomv1alpha1.MaintenanceWindowSpec
+		expected urrecon.MaintenanceWindow
+	}{
+		{
+			name:     "once in utc by default",
+			spec:     uptimerobotcomv1alpha1.MaintenanceWindowSpec{Type: uptimerobotcomv1alpha1.MAINTENANCE_ONCE, Date: "2024-06-15", Start: "22:00", Duration: 30},
+			expected: urrecon.MaintenanceWindow{Type: uptimerobot.MWindowTypeOnce, Start: "1718488800", Duration: 30},
+		},
+		{
+			name:     "once west of utc crosses into the next day",
+			spec:     uptimerobotcomv1alpha1.MaintenanceWindowSpec{Type: uptimerobotcomv1alpha1.MAINTENANCE_ONCE, Date: "2024-01-01", Start: "23:00", Duration: 60, Timezone: "America/New_York"},
+			expected: urrecon.MaintenanceWindow{Type: uptimerobot.MWindowTypeOnce, Start: "1704168000", Duration: 60},
+		},
+		{
+			name:     "once east of utc crosses into the previous day of a leap year",
+			spec:     uptimerobotcomv1alpha1.MaintenanceWindowSpec{Type: uptimerobotcomv1alpha1.MAINTENANCE_ONCE, Date: "2024-03-01", Start: "00:30", Duration: 15, Timezone: "Europe/Berlin"},
+			expected: urrecon.MaintenanceWindow{Type: uptimerobot.MWindowTypeOnce, Start: "1709249400", Duration: 15},
+		},
+		{
+			name:     "daily keeps the start time",
+			spec:     uptimerobotcomv1alpha1.MaintenanceWindowSpec{Type: uptimerobotcomv1alpha1.MAINTENANCE_DAILY, Start: "03:15", Duration: 10},
+			expected: urrecon.MaintenanceWindow{Type: uptimerobot.MWindowTypeDaily, Start: "03:15", Duration: 10},
+		},
+		{
+			name:     "weekly sorts its days",
+			spec:     uptimerobotcomv1alpha1.MaintenanceWindowSpec{Type: uptimerobotcomv1alpha1.MAINTENANCE_WEEKLY, Days: []uptimerobotcomv1alpha1.MaintenanceWindowDay{7, 2, 4}, Start: "22:00", Duration: 60},
+			expected: urrecon.MaintenanceWindow{Type: uptimerobot.MWindowTypeWeekly, Value: "2-4-7", Start: "22:00", Duration: 60},
+		},
+		{
+			name:     "monthly on the last day",
+			spec:     uptimerobotcomv1alpha1.MaintenanceWindowSpec{Type: uptimerobotcomv1alpha1.MAINTENANCE_MONTHLY, Days: []uptimerobotcomv1alpha1.MaintenanceWindowDay{31, 1}, Start: "00:00", Duration: 120},
+			expected: urrecon.MaintenanceWindow{Type: uptimerobot.MWindowTypeMonthly, Value: "1-31", Start: "00:00", Duration: 120},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			mwindowObj := urrecon.MaintenanceWindow{}
+			err := setMaintenanceWindowSchedule(&mwindowObj, testCase.spec)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if mwindowObj != testCase.expected {
+				t.Errorf("expected %+v, got %+v", testCase.expected, mwindowObj)
+			}
+		})
+	}
+}
+
+func TestSetMaintenanceWindowScheduleRejectsInvalidSpecs(t *testing.T) {
+	testCases := []struct {
+		name string
+		spec uptimerobotcomv1alpha1.MaintenanceWindowSpec
+	}{
+		{"unknown timezone", uptimerobotcomv1alpha1.MaintenanceWindowSpec{Type: uptimerobotcomv1alpha1.MAINTENANCE_ONCE, Date: "2024-06-15", Start: "22:00", Timezone: "Mars/Olympus"}},
+		{"timezone of a recurring window", uptimerobotcomv1alpha1.MaintenanceWindowSpec{Type: uptimerobotcomv1alpha1.MAINTENANCE_DAILY, Start: "22:00", Timezone: "Europe/Berlin"}},
+		{"invalid date", uptimerobotcomv1alpha1.MaintenanceWindowSpec{Type: uptimerobotcomv1alpha1.MAINTENANCE_ONCE, Date: "2023-02-29", Start: "22:00"}},
+		{"unknown type", uptimerobotcomv1alpha1.MaintenanceWindowSpec{Type: "yearly", Start: "22:00"}},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := setMaintenanceWindowSchedule(&urrecon.MaintenanceWindow{}, testCase.spec)
+			if err == nil {
+				t.Errorf("expected an error")
+			}
+		})
+	}
+}
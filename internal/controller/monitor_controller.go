@@ -18,35 +18,43 @@ package controller
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	uptimerobotcomv1alpha1 "github.com/luckielordie/uptime-robot-operator/api/v1alpha1"
 	"github.com/luckielordie/uptime-robot-operator/internal/controller/urrecon"
 	"github.com/luckielordie/uptime-robot-operator/internal/uptimerobot"
 )
 
-type MonitorAPIClient interface {
-	uptimerobot.MonitorCreator
-	uptimerobot.MonitorEditor
-	uptimerobot.MonitorGetter
-	uptimerobot.MonitorDeleter
-}
-
 // MonitorReconciler reconciles a Monitor object
 type MonitorReconciler struct {
 	client.Client
-	urrecon.MonitorApiReconciler
-	Scheme        *runtime.Scheme
-	MonitorClient MonitorAPIClient
+	Scheme    *runtime.Scheme
+	Clients   *uptimerobot.ClientPool
+	Snapshots *urrecon.SnapshotCaches
+	Recorder  record.EventRecorder
 }
 
 func getMonitor(ctx context.Context, reader client.Reader, req ctrl.Request) (uptimerobotcomv1alpha1.Monitor, error) {
@@ -65,30 +73,640 @@ func getMonitor(ctx context.Context, reader client.Reader, req ctrl.Request) (up
 	return monitor, nil
 }
 
-func getListOfAlertContactIds(ctx context.Context, reader client.Reader, labels map[string]string) ([]string, error) {
-	logger := log.FromContext(ctx)
-	alertContacts := uptimerobotcomv1alpha1.AlertContactList{}
-	var matchingLabels client.MatchingLabels = labels
-	err := reader.List(ctx, &alertContacts, matchingLabels)
+func MonitorTypeToInt(monitorType uptimerobotcomv1alpha1.MonitorType) (int, error) {
+	switch monitorType {
+	case uptimerobotcomv1alpha1.HTTP:
+		return 1, nil
+	case uptimerobotcomv1alpha1.KEYWORD:
+		return 2, nil
+	case uptimerobotcomv1alpha1.PING:
+		return 3, nil
+	case uptimerobotcomv1alpha1.PORT:
+		return 4, nil
+	case uptimerobotcomv1alpha1.HEARTBEAT:
+		return 5, nil
+	default:
+		return 0, errors.New("unrecognised monitor type")
+	}
+}
+
+func IntToMonitorType(monitorTypeId int) (uptimerobotcomv1alpha1.MonitorType, error) {
+	switch monitorTypeId {
+	case 1:
+		return uptimerobotcomv1alpha1.HTTP, nil
+	case 2:
+		return uptimerobotcomv1alpha1.KEYWORD, nil
+	case 3:
+		return uptimerobotcomv1alpha1.PING, nil
+	case 4:
+		return uptimerobotcomv1alpha1.PORT, nil
+	case 5:
+		return uptimerobotcomv1alpha1.HEARTBEAT, nil
+	default:
+		return "", errors.New("unrecognised monitor type")
+	}
+}
+
+func IntToMonitorState(status int) (uptimerobotcomv1alpha1.MonitorState, error) {
+	switch status {
+	case uptimerobot.MonitorStatusPaused:
+		return uptimerobotcomv1alpha1.STATE_PAUSED, nil
+	case uptimerobot.MonitorStatusNotChecked:
+		return uptimerobotcomv1alpha1.STATE_NOT_CHECKED, nil
+	case uptimerobot.MonitorStatusUp:
+		return uptimerobotcomv1alpha1.STATE_UP, nil
+	case uptimerobot.MonitorStatusSeemsDown:
+		return uptimerobotcomv1alpha1.STATE_SEEMS_DOWN, nil
+	case uptimerobot.MonitorStatusDown:
+		return uptimerobotcomv1alpha1.STATE_DOWN, nil
+	default:
+		return "", errors.New("unrecognised monitor status")
+	}
+}
+
+// setMonitorHealth reports the state UptimeRobot last saw the monitor in. The logs only cover the
+// latest few changes, so an older downtime is kept until a newer one is logged.
+func setMonitorHealth(status *uptimerobotcomv1alpha1.MonitorStatus, details uptimerobot.MonitorDetails) error {
+	state, err := IntToMonitorState(details.Status)
+	if err != nil {
+		return err
+	}
+	status.State = state
+
+	//logs are returned newest first
+	status.LastStateChange = nil
+	if len(details.Logs) > 0 {
+		lastStateChange := metav1.NewTime(time.Unix(int64(details.Logs[0].Datetime), 0))
+		status.LastStateChange = &lastStateChange
+	}
+
+	for _, entry := range details.Logs {
+		if entry.Type != uptimerobot.MonitorLogDown {
+			continue
+		}
+
+		status.LastDowntime = &uptimerobotcomv1alpha1.MonitorDowntime{
+			Start:    metav1.NewTime(time.Unix(int64(entry.Datetime), 0)),
+			Duration: metav1.Duration{Duration: time.Duration(entry.Duration) * time.Second},
+			Reason:   entry.Reason.Detail,
+		}
+		break
+	}
+
+	return nil
+}
+
+// setMonitorUptime reports the uptime ratios of the periods the spec asks for, the snapshot may hold
+// the ratios of periods other monitors asked for too
+func setMonitorUptime(status *uptimerobotcomv1alpha1.MonitorStatus, periods []int, details uptimerobot.MonitorDetails) {
+	status.UptimeRatios = nil
+	for _, period := range periods {
+		ratio, ok := details.UptimeRatios[period]
+		if !ok {
+			continue
+		}
+		status.UptimeRatios = append(status.UptimeRatios, uptimerobotcomv1alpha1.UptimeRatio{Days: period, Ratio: ratio})
+	}
+
+	status.AllTimeUptimeRatio = string(details.AllTimeUptimeRatio)
+	status.AverageResponseTime = string(details.AverageResponseTime)
+}
+
+func PortSubTypeToInt(subType uptimerobotcomv1alpha1.PortSubType) (int, error) {
+	switch subType {
+	case uptimerobotcomv1alpha1.PORT_HTTP:
+		return 1, nil
+	case uptimerobotcomv1alpha1.PORT_HTTPS:
+		return 2, nil
+	case uptimerobotcomv1alpha1.PORT_FTP:
+		return 3, nil
+	case uptimerobotcomv1alpha1.PORT_SMTP:
+		return 4, nil
+	case uptimerobotcomv1alpha1.PORT_POP3:
+		return 5, nil
+	case uptimerobotcomv1alpha1.PORT_IMAP:
+		return 6, nil
+	case uptimerobotcomv1alpha1.PORT_CUSTOM:
+		return 99, nil
+	default:
+		return 0, errors.New("unrecognised port sub type")
+	}
+}
+
+func KeywordTypeToInt(keywordType uptimerobotcomv1alpha1.KeywordType) (int, error) {
+	switch keywordType {
+	case uptimerobotcomv1alpha1.KEYWORD_EXISTS:
+		return 1, nil
+	case uptimerobotcomv1alpha1.KEYWORD_NOT_EXISTS:
+		return 2, nil
+	default:
+		return 0, errors.New("unrecognised keyword type")
+	}
+}
+
+// setMonitorType fills in the type specific fields of the api monitor from the spec, taking the
+// keyword from keywordValue as it may have been read from a Secret
+func setMonitorType(monitorObj *urrecon.Monitor, spec uptimerobotcomv1alpha1.MonitorSpec, keywordValue string) error {
+	monitorType := spec.Type
+	if monitorType == "" {
+		monitorType = uptimerobotcomv1alpha1.HTTP
+	}
+
+	monitorTypeId, err := MonitorTypeToInt(monitorType)
+	if err != nil {
+		return err
+	}
+	monitorObj.Type = monitorTypeId
+
+	monitorObj.SubType = 0
+	monitorObj.Port = 0
+	if spec.Port != nil {
+		subTypeId, err := PortSubTypeToInt(spec.Port.SubType)
+		if err != nil {
+			return err
+		}
+		monitorObj.SubType = subTypeId
+		monitorObj.Port = spec.Port.Port
+	}
+
+	monitorObj.KeywordType = 0
+	monitorObj.KeywordCaseType = 0
+	monitorObj.KeywordValue = ""
+	if spec.Keyword != nil {
+		keywordTypeId, err := KeywordTypeToInt(spec.Keyword.Type)
+		if err != nil {
+			return err
+		}
+		monitorObj.KeywordType = keywordTypeId
+		monitorObj.KeywordValue = keywordValue
+		// 0 is case sensitive, 1 is case insensitive
+		if !spec.Keyword.CaseSensitive {
+			monitorObj.KeywordCaseType = 1
+		}
+	}
+
+	return nil
+}
+
+const (
+	DEFAULT_MONITOR_INTERVAL = 300
+	DEFAULT_MONITOR_TIMEOUT  = 30
+)
+
+// setMonitorSchedule fills in the interval and timeout of the api monitor from the spec, raising the
+// interval to the account's minimum, and returns the condition saying whether spec.interval was used as is
+func setMonitorSchedule(monitorObj *urrecon.Monitor, spec uptimerobotcomv1alpha1.MonitorSpec, minimumInterval int) metav1.Condition {
+	interval := spec.Interval
+	if interval == 0 {
+		interval = DEFAULT_MONITOR_INTERVAL
+	}
+
+	condition := metav1.Condition{
+		Type:    uptimerobotcomv1alpha1.CONDITION_INTERVAL_ACCEPTED,
+		Status:  metav1.ConditionTrue,
+		Reason:  uptimerobotcomv1alpha1.REASON_INTERVAL_ACCEPTED,
+		Message: fmt.Sprintf("checking every %ds", interval),
+	}
+	if interval < minimumInterval {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = uptimerobotcomv1alpha1.REASON_BELOW_ACCOUNT_MINIMUM
+		condition.Message = fmt.Sprintf("interval of %ds is below the account's minimum of %ds, checking every %ds instead", interval, minimumInterval, minimumInterval)
+		interval = minimumInterval
+	}
+	monitorObj.Interval = interval
+
+	monitorObj.Timeout = 0
+	switch spec.Type {
+	case uptimerobotcomv1alpha1.PING, uptimerobotcomv1alpha1.HEARTBEAT:
+	default:
+		monitorObj.Timeout = spec.Timeout
+		if monitorObj.Timeout == 0 {
+			monitorObj.Timeout = DEFAULT_MONITOR_TIMEOUT
+		}
+	}
+
+	return condition
+}
+
+// getMonitorPause works out whether the monitor should be paused, by its spec, its annotation, its
+// Account or its Namespace, and returns the condition saying so
+func getMonitorPause(ctx context.Context, reader client.Reader, monitor *uptimerobotcomv1alpha1.Monitor, account *uptimerobotcomv1alpha1.Account) (metav1.Condition, error) {
+	condition := metav1.Condition{
+		Type:   uptimerobotcomv1alpha1.CONDITION_PAUSED,
+		Status: metav1.ConditionTrue,
+	}
+
+	namespace := corev1.Namespace{}
+	err := reader.Get(ctx, types.NamespacedName{Name: monitor.Namespace}, &namespace)
+	if err != nil {
+		return condition, err
+	}
+
+	switch {
+	case monitor.Spec.Paused:
+		condition.Reason = uptimerobotcomv1alpha1.REASON_PAUSED_BY_SPEC
+		condition.Message = "paused by spec.paused"
+	case monitor.Annotations[uptimerobotcomv1alpha1.ANNOTATION_PAUSED] == "true":
+		condition.Reason = uptimerobotcomv1alpha1.REASON_PAUSED_BY_ANNOTATION
+		condition.Message = fmt.Sprintf("paused by the %s annotation", uptimerobotcomv1alpha1.ANNOTATION_PAUSED)
+	case account != nil && account.Spec.PauseMonitors:
+		condition.Reason = uptimerobotcomv1alpha1.REASON_PAUSED_BY_ACCOUNT
+		condition.Message = fmt.Sprintf("paused by account %s", account.Name)
+	case namespace.Annotations[uptimerobotcomv1alpha1.ANNOTATION_PAUSED] == "true":
+		condition.Reason = uptimerobotcomv1alpha1.REASON_PAUSED_BY_NAMESPACE
+		condition.Message = fmt.Sprintf("paused by the %s annotation of namespace %s", uptimerobotcomv1alpha1.ANNOTATION_PAUSED, namespace.Name)
+	default:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = uptimerobotcomv1alpha1.REASON_NOT_PAUSED
+		condition.Message = "monitoring"
+	}
+
+	return condition, nil
+}
+
+func HttpMethodToInt(method uptimerobotcomv1alpha1.HttpMethod) (int, error) {
+	switch method {
+	case uptimerobotcomv1alpha1.HTTP_HEAD:
+		return 1, nil
+	case uptimerobotcomv1alpha1.HTTP_GET:
+		return 2, nil
+	case uptimerobotcomv1alpha1.HTTP_POST:
+		return 3, nil
+	case uptimerobotcomv1alpha1.HTTP_PUT:
+		return 4, nil
+	case uptimerobotcomv1alpha1.HTTP_PATCH:
+		return 5, nil
+	case uptimerobotcomv1alpha1.HTTP_DELETE:
+		return 6, nil
+	case uptimerobotcomv1alpha1.HTTP_OPTIONS:
+		return 7, nil
+	default:
+		return 0, errors.New("unrecognised http method")
+	}
+}
+
+func HttpAuthTypeToInt(authType uptimerobotcomv1alpha1.HttpAuthType) (int, error) {
+	switch authType {
+	case uptimerobotcomv1alpha1.HTTP_AUTH_BASIC, "":
+		return 1, nil
+	case uptimerobotcomv1alpha1.HTTP_AUTH_DIGEST:
+		return 2, nil
+	default:
+		return 0, errors.New("unrecognised http auth type")
+	}
+}
+
+// encodeHttpStatuses formats status codes the way custom_http_statuses expects, e.g. 404:0_200:1
+// where 1 means up and 0 means down
+func encodeHttpStatuses(statuses uptimerobotcomv1alpha1.HttpStatuses) string {
+	codes := map[int]int{}
+	for _, code := range statuses.Up {
+		codes[int(code)] = 1
+	}
+	for _, code := range statuses.Down {
+		codes[int(code)] = 0
+	}
+
+	sortedCodes := make([]int, 0, len(codes))
+	for code := range codes {
+		sortedCodes = append(sortedCodes, code)
+	}
+	sort.Ints(sortedCodes)
+
+	encoded := make([]string, 0, len(sortedCodes))
+	for _, code := range sortedCodes {
+		encoded = append(encoded, fmt.Sprintf("%d:%d", code, codes[code]))
+	}
+
+	return strings.Join(encoded, "_")
+}
+
+// getMonitorHttp builds the http settings of the api monitor from the spec, reading credentials from their Secrets
+func getMonitorHttp(ctx context.Context, reader client.Reader, namespace string, spec *uptimerobotcomv1alpha1.HttpConfig) (urrecon.MonitorHttp, error) {
+	monitorHttp := urrecon.MonitorHttp{}
+	if spec == nil {
+		return monitorHttp, nil
+	}
+
+	if spec.Method != "" {
+		method, err := HttpMethodToInt(spec.Method)
+		if err != nil {
+			return monitorHttp, err
+		}
+		monitorHttp.Method = strconv.Itoa(method)
+	}
+
+	if spec.Body != nil {
+		if spec.Body.Form != nil {
+			form, err := json.Marshal(spec.Body.Form)
+			if err != nil {
+				return monitorHttp, err
+			}
+			monitorHttp.PostType = 1
+			monitorHttp.PostValue = string(form)
+		} else {
+			monitorHttp.PostType = 2
+			monitorHttp.PostValue = spec.Body.Raw
+		}
+
+		if spec.Body.ContentType == uptimerobotcomv1alpha1.CONTENT_TYPE_JSON {
+			monitorHttp.PostContentType = 1
+		}
+	}
+
+	if len(spec.Headers) > 0 {
+		headerValues := map[string]string{}
+		for _, header := range spec.Headers {
+			value, err := getValue(ctx, reader, namespace, header.ValueOrSecret)
+			if err != nil {
+				return monitorHttp, err
+			}
+			headerValues[header.Name] = value
+		}
+
+		headers, err := json.Marshal(headerValues)
+		if err != nil {
+			return monitorHttp, err
+		}
+		monitorHttp.Headers = string(headers)
+	}
+
+	if spec.Statuses != nil {
+		monitorHttp.Statuses = encodeHttpStatuses(*spec.Statuses)
+	}
+
+	if spec.Auth != nil {
+		authType, err := HttpAuthTypeToInt(spec.Auth.Type)
+		if err != nil {
+			return monitorHttp, err
+		}
+
+		username, err := getValue(ctx, reader, namespace, spec.Auth.Username)
+		if err != nil {
+			return monitorHttp, err
+		}
+
+		password, err := getSecretKeyValue(ctx, reader, namespace, spec.Auth.Password.ValueFrom.SecretKeyRef)
+		if err != nil {
+			return monitorHttp, err
+		}
+
+		monitorHttp.AuthType = authType
+		monitorHttp.Username = username
+		monitorHttp.Password = password
+	}
+
+	return monitorHttp, nil
+}
+
+// getAlertContactNamespaces returns the sorted namespaces the monitor selects AlertContacts in, its own and
+// those alertContactNamespaces selects
+func getAlertContactNamespaces(ctx context.Context, reader client.Reader, monitor *uptimerobotcomv1alpha1.Monitor) ([]string, error) {
+	namespaces := []string{monitor.Namespace}
+	if monitor.Spec.AlertContactNamespaces == nil {
+		return namespaces, nil
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(monitor.Spec.AlertContactNamespaces)
+	if err != nil {
+		return nil, invalidConfig(err)
+	}
+
+	namespaceList := corev1.NamespaceList{}
+	err = reader.List(ctx, &namespaceList, client.MatchingLabelsSelector{Selector: selector})
+	if err != nil {
+		log.FromContext(ctx).Info("failed to retrieve alert contact namespaces for monitor with selector", "selector", selector.String())
+		return nil, err
+	}
+
+	for _, namespace := range namespaceList.Items {
+		if namespace.Name != monitor.Namespace {
+			namespaces = append(namespaces, namespace.Name)
+		}
+	}
+	sort.Strings(namespaces)
+
+	return namespaces, nil
+}
+
+// getListOfAlertContacts returns the synced AlertContacts the selector matches in the namespaces
+func getListOfAlertContacts(ctx context.Context, reader client.Reader, namespaces []string, labelSelector metav1.LabelSelector) ([]uptimerobotcomv1alpha1.AlertContact, error) {
+	selector, err := metav1.LabelSelectorAsSelector(&labelSelector)
+	if err != nil {
+		return nil, invalidConfig(err)
+	}
+
+	var synced []uptimerobotcomv1alpha1.AlertContact
+	for _, namespace := range namespaces {
+		alertContacts := uptimerobotcomv1alpha1.AlertContactList{}
+		err = reader.List(ctx, &alertContacts, client.InNamespace(namespace), client.MatchingLabelsSelector{Selector: selector})
+		if err != nil {
+			log.FromContext(ctx).Info("failed to retrieve alert contacts for monitor with selector", "selector", selector.String(), "namespace", namespace)
+			return nil, err
+		}
+
+		for _, ac := range alertContacts.Items {
+			if ac.Status.Id != "" {
+				synced = append(synced, ac)
+			}
+		}
+	}
+
+	return synced, nil
+}
+
+// alertContactInAccount tells whether an AlertContact belongs to the UptimeRobot account of a monitor, whose
+// Account is account or nil for the default account. In the monitor's namespace the contact has to reference
+// the same Account, in other namespaces an Account of the same UptimeRobot login or the default account too.
+func alertContactInAccount(ctx context.Context, reader client.Reader, monitor *uptimerobotcomv1alpha1.Monitor, account *uptimerobotcomv1alpha1.Account, alertContact *uptimerobotcomv1alpha1.AlertContact) (bool, error) {
+	if alertContact.Namespace == monitor.Namespace {
+		return sameAccountRef(monitor.Spec.AccountRef, alertContact.Spec.AccountRef), nil
+	}
+
+	if account == nil || alertContact.Spec.AccountRef == nil {
+		return account == nil && alertContact.Spec.AccountRef == nil, nil
+	}
+
+	alertContactAccount, err := getAccountForRef(ctx, reader, alertContact.Namespace, alertContact.Spec.AccountRef)
+	if err != nil {
+		return false, client.IgnoreNotFound(err)
+	}
+
+	return account.Status.Email != "" && alertContactAccount.Status.Email == account.Status.Email, nil
+}
+
+// getMonitorAlertContacts returns the sorted id_threshold_recurrence triples of the contacts in the monitor's
+// account it notifies, and the AlertContacts they were resolved from. Contacts are notified as soon as the
+// monitor goes down unless one of its alert routes selects them.
+func getMonitorAlertContacts(ctx context.Context, reader client.Reader, monitor *uptimerobotcomv1alpha1.Monitor, account *uptimerobotcomv1alpha1.Account) ([]string, []uptimerobotcomv1alpha1.ResolvedAlertContact, error) {
+	namespaces, err := getAlertContactNamespaces(ctx, reader, monitor)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resolved := map[string]uptimerobotcomv1alpha1.ResolvedAlertContact{}
+	addAlertContacts := func(labelSelector metav1.LabelSelector, threshold int, recurrence int) error {
+		alertContacts, err := getListOfAlertContacts(ctx, reader, namespaces, labelSelector)
+		if err != nil {
+			return err
+		}
+
+		for _, alertContact := range alertContacts {
+			if _, ok := resolved[alertContact.Status.Id]; ok {
+				continue
+			}
+
+			inAccount, err := alertContactInAccount(ctx, reader, monitor, account, &alertContact)
+			if err != nil {
+				return err
+			}
+			if !inAccount {
+				continue
+			}
+
+			resolved[alertContact.Status.Id] = uptimerobotcomv1alpha1.ResolvedAlertContact{
+				Namespace:  alertContact.Namespace,
+				Name:       alertContact.Name,
+				Id:         alertContact.Status.Id,
+				Threshold:  threshold,
+				Recurrence: recurrence,
+			}
+		}
+
+		return nil
+	}
+
+	//the first route selecting a contact wins, so routes are resolved before the contacts notified straight away
+	for _, route := range monitor.Spec.AlertRoutes {
+		err := addAlertContacts(route.AlertContacts, route.Threshold, route.Recurrence)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	//an empty alertContacts selects every contact, which would undo the routes
+	alertContactsSelector := monitor.Spec.AlertContacts
+	if len(monitor.Spec.AlertRoutes) == 0 || len(alertContactsSelector.MatchLabels) > 0 || len(alertContactsSelector.MatchExpressions) > 0 {
+		err := addAlertContacts(alertContactsSelector, 0, 0)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	var encoded []string
+	var alertContacts []uptimerobotcomv1alpha1.ResolvedAlertContact
+	for _, alertContact := range resolved {
+		id, err := strconv.Atoi(alertContact.Id)
+		if err != nil {
+			return nil, nil, fmt.Errorf("alert contact %s/%s has invalid id %q: %w", alertContact.Namespace, alertContact.Name, alertContact.Id, err)
+		}
+
+		encoded = append(encoded, uptimerobot.MonitorAlertContact{
+			Id:         uptimerobot.OptionalInt(id),
+			Threshold:  uptimerobot.OptionalInt(alertContact.Threshold),
+			Recurrence: uptimerobot.OptionalInt(alertContact.Recurrence),
+		}.String())
+		alertContacts = append(alertContacts, alertContact)
+	}
+	sort.Strings(encoded)
+	sort.Slice(alertContacts, func(i, j int) bool {
+		if alertContacts[i].Namespace != alertContacts[j].Namespace {
+			return alertContacts[i].Namespace < alertContacts[j].Namespace
+		}
+		return alertContacts[i].Name < alertContacts[j].Name
+	})
+
+	return encoded, alertContacts, nil
+}
+
+// normalizeAlertRoutes drops the threshold and recurrence alert routes give contacts when the account's plan
+// doesn't store them, the api would list the contacts without them and the monitor would be edited on every
+// reconcile. The returned condition says whether the routes were accepted.
+func normalizeAlertRoutes(alertContacts []string, resolved []uptimerobotcomv1alpha1.ResolvedAlertContact, alertThresholdsSupported bool) ([]string, metav1.Condition) {
+	condition := metav1.Condition{
+		Type:   uptimerobotcomv1alpha1.CONDITION_ALERT_ROUTES_ACCEPTED,
+		Status: metav1.ConditionTrue,
+		Reason: uptimerobotcomv1alpha1.REASON_ALERT_ROUTES_ACCEPTED,
+	}
+	if alertThresholdsSupported {
+		return alertContacts, condition
+	}
+
+	routed := false
+	for _, alertContact := range resolved {
+		if alertContact.Threshold != 0 || alertContact.Recurrence != 0 {
+			routed = true
+		}
+	}
+	if !routed {
+		return alertContacts, condition
+	}
+
+	normalized := make([]string, len(alertContacts))
+	for i, alertContact := range alertContacts {
+		id, _, _ := strings.Cut(alertContact, "_")
+		normalized[i] = id + "_0_0"
+	}
+	sort.Strings(normalized)
+
+	condition.Status = metav1.ConditionFalse
+	condition.Reason = uptimerobotcomv1alpha1.REASON_UNSUPPORTED_BY_PLAN
+	condition.Message = "the account's plan doesn't store alert thresholds and recurrences, contacts are notified as soon as the monitor goes down"
+	return normalized, condition
+}
+
+// getListOfMaintenanceWindowIds returns the sorted ids of the synced MaintenanceWindows of the Account
+// accountRef names that the selector matches in the namespace
+func getListOfMaintenanceWindowIds(ctx context.Context, reader client.Reader, namespace string, accountRef *corev1.LocalObjectReference, labelSelector metav1.LabelSelector) ([]string, error) {
+	selector, err := metav1.LabelSelectorAsSelector(&labelSelector)
+	if err != nil {
+		return nil, invalidConfig(err)
+	}
+
+	//an empty selector attaches no windows rather than every window
+	if selector.Empty() {
+		return nil, nil
+	}
+
+	mwindows := uptimerobotcomv1alpha1.MaintenanceWindowList{}
+	err = reader.List(ctx, &mwindows, client.InNamespace(namespace), client.MatchingLabelsSelector{Selector: selector})
 	if err != nil {
-		logger.Info("failed to retrieve alert contacts for monitor with labels", "labels", labels)
+		log.FromContext(ctx).Info("failed to retrieve maintenance windows for monitor with selector", "selector", selector.String())
 		return nil, err
 	}
 
 	var ids []string
-	for _, ac := range alertContacts.Items {
-		if ac.Status.Id != "" {
-			ids = append(ids, ac.Status.Id)
+	for _, mwindow := range mwindows.Items {
+		if mwindow.Status.Id != "" && sameAccountRef(accountRef, mwindow.Spec.AccountRef) {
+			ids = append(ids, mwindow.Status.Id)
 		}
 	}
+	sort.Strings(ids)
 
 	return ids, nil
 }
 
+// monitorDrifted tells whether an edit of the monitor corrected a change made on UptimeRobot. It didn't when what
+// was sent differs from what was last sent, because the spec, a Secret, the selected contacts or windows, the
+// account's minimum interval or the pause changed.
+func monitorDrifted(monitor *uptimerobotcomv1alpha1.Monitor, monitorObj urrecon.Monitor, alertContacts []uptimerobotcomv1alpha1.ResolvedAlertContact, pausedCondition metav1.Condition) bool {
+	return monitor.Status.ObservedGeneration == monitor.Generation &&
+		monitor.Status.HttpSettingsHash == monitorObj.HttpHash &&
+		monitor.Status.Interval == monitorObj.Interval &&
+		reflect.DeepEqual(monitor.Status.AlertContacts, alertContacts) &&
+		reflect.DeepEqual(monitor.Status.MaintenanceWindows, monitorObj.MaintenanceWindows) &&
+		meta.IsStatusConditionPresentAndEqual(monitor.Status.Conditions, uptimerobotcomv1alpha1.CONDITION_PAUSED, pausedCondition.Status)
+}
+
 //+kubebuilder:rbac:groups=uptimerobot.com,resources=monitors,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=uptimerobot.com,resources=monitors/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=uptimerobot.com,resources=monitors/finalizers,verbs=update
 //+kubebuilder:rbac:groups=uptimerobot.com,resources=alertcontacts,verbs=get;list;watch
+//+kubebuilder:rbac:groups=uptimerobot.com,resources=maintenancewindows,verbs=get;list;watch
+//+kubebuilder:rbac:groups=uptimerobot.com,resources=accounts,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -106,26 +724,65 @@ func (reconciler *MonitorReconciler) Reconcile(ctx context.Context, request ctrl
 	if err != nil {
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
+	originalStatus := monitor.Status.DeepCopy()
+
+	account, err := getAccountForRef(ctx, reconciler, monitor.Namespace, monitor.Spec.AccountRef)
+	apiClient := reconciler.Clients.Default()
+	if err == nil && account != nil {
+		apiClient, err = getAccountClient(ctx, reconciler, reconciler.Clients, account)
+	}
+	if err != nil {
+		//a missing account can't come back to delete with, so a resource being deleted is let go
+		if apierrors.IsNotFound(err) && !monitor.DeletionTimestamp.IsZero() {
+			return ctrl.Result{}, releaseFinalizer(ctx, reconciler.Client, reconciler.Recorder, &monitor, FINALIZER_TOKEN, monitor.Status.Id, err)
+		}
 
+		logger.Error(err, "failed to resolve uptimerobot account", "account", monitor.Spec.AccountRef)
+		if apierrors.IsNotFound(err) {
+			err = invalidConfig(err)
+		}
+
+		return recordSyncFailure(ctx, reconciler.Recorder, reconciler.Client.Status(), &monitor, &monitor.Status.Conditions, err)
+	}
+
+	snapshot := reconciler.Snapshots.For(apiClient)
+	uptimeRatioPeriods := make([]int, len(monitor.Spec.UptimeRatioPeriods))
+	for i, period := range monitor.Spec.UptimeRatioPeriods {
+		uptimeRatioPeriods[i] = int(period)
+	}
+	snapshot.RequestUptimeRatios(uptimeRatioPeriods)
 	result, err := Finalize(ctx, reconciler.Client, &monitor, FINALIZER_TOKEN, func(context.Context) error {
+		//nothing was created on the api, so there is nothing to delete
+		if monitor.Status.Id == "" {
+			return nil
+		}
+
 		idInt, err := strconv.Atoi(monitor.Status.Id)
 		if err != nil {
 			return err
 		}
 
-		_, err = reconciler.MonitorClient.DeleteMonitor(ctx, idInt)
+		_, err = apiClient.DeleteMonitor(ctx, idInt)
 		if err != nil {
-			errStr := err.Error()
-			if strings.Contains(errStr, "not_found") {
+			if uptimerobot.IsNotFound(err) {
 				return nil
 			}
-			logger.Error(err, "failed to delete monitor", "id", monitor.Status.Id)
+			if uptimerobot.IsRateLimited(err) {
+				return err
+			}
+			logger.Error(err, "failed to delete monitor", "id", monitor.Status.Id, "reason", apiErrorReason(err))
 			return err
 		}
 
+		snapshot.RemoveMonitor(monitor.Status.Id)
+		recordDeleteEvent(reconciler.Recorder, &monitor, monitor.Status.Id)
 		return nil
 	})
 	if err != nil || result != controllerutil.OperationResultNone {
+		if rateLimitedResult, ok := requeueIfRateLimited(ctx, err); ok {
+			return rateLimitedResult, nil
+		}
+
 		if result != controllerutil.OperationResultNone {
 			logger.Error(err, "failed finalizing monitor")
 		}
@@ -133,34 +790,184 @@ func (reconciler *MonitorReconciler) Reconcile(ctx context.Context, request ctrl
 		return ctrl.Result{}, err
 	}
 
-	alertContactIds, err := getListOfAlertContactIds(ctx, reconciler, monitor.Spec.AlertContacts.MatchLabels)
+	statusWriter := reconciler.Client.Status()
+	pausedCondition, err := getMonitorPause(ctx, reconciler, &monitor, account)
 	if err != nil {
+		logger.Error(err, "failed to check whether the monitor is paused")
 		return ctrl.Result{}, err
 	}
 
+	alertContacts, resolvedAlertContacts, err := getMonitorAlertContacts(ctx, reconciler, &monitor, account)
+	if err != nil {
+		logger.Error(err, "failed to select alert contacts")
+		return recordSyncFailure(ctx, reconciler.Recorder, statusWriter, &monitor, &monitor.Status.Conditions, err)
+	}
+
+	maintenanceWindowIds, err := getListOfMaintenanceWindowIds(ctx, reconciler, monitor.Namespace, monitor.Spec.AccountRef, monitor.Spec.MaintenanceWindows)
+	if err != nil {
+		logger.Error(err, "failed to select maintenance windows")
+		return recordSyncFailure(ctx, reconciler.Recorder, statusWriter, &monitor, &monitor.Status.Conditions, err)
+	}
+
+	//the account's plan limits how often monitors can be checked, it is given in minutes, and decides
+	//whether alert routes are stored
+	minimumInterval := 0
+	alertThresholdsSupported := false
+	if account != nil {
+		minimumInterval = account.Status.MonitorInterval * 60
+		alertThresholdsSupported = account.Status.AlertThresholdsSupported
+	} else {
+		accountDetails, err := snapshot.AccountDetails(ctx)
+		if err != nil {
+			logger.Error(err, "failed to retrieve account details", "reason", apiErrorReason(err))
+			return recordSyncFailure(ctx, reconciler.Recorder, statusWriter, &monitor, &monitor.Status.Conditions, err)
+		}
+		minimumInterval = accountDetails.Account.MonitorInterval * 60
+		alertThresholdsSupported = accountDetails.SupportsAlertThresholds()
+	}
+	alertContacts, alertRoutesCondition := normalizeAlertRoutes(alertContacts, resolvedAlertContacts, alertThresholdsSupported)
+
+	monitorHttp, err := getMonitorHttp(ctx, reconciler, monitor.Namespace, monitor.Spec.Http)
+	if err != nil {
+		logger.Error(err, "failed to build http settings")
+		return recordSyncFailure(ctx, reconciler.Recorder, statusWriter, &monitor, &monitor.Status.Conditions, invalidConfig(err))
+	}
+	if monitor.Spec.Ssl != nil {
+		monitorHttp.IgnoreSslErrors = monitor.Spec.Ssl.IgnoreErrors
+		monitorHttp.SslExpirationReminder = monitor.Spec.Ssl.ExpirationReminder
+	}
+	monitorHttp.DisableDomainExpireNotifications = monitor.Spec.DisableDomainExpiryNotifications
+	//editMonitor resets an unset method to HEAD, which can't read the body a keyword is looked for in
+	if monitor.Spec.Type == uptimerobotcomv1alpha1.KEYWORD && monitorHttp.Method == "" {
+		monitorHttp.Method = strconv.Itoa(uptimerobot.HttpMethodGet)
+	}
+
+	keywordValue := ""
+	if monitor.Spec.Keyword != nil {
+		keywordValue, err = getValue(ctx, reconciler, monitor.Namespace, monitor.Spec.Keyword.ValueOrSecret)
+		if err != nil {
+			logger.Error(err, "failed to read keyword")
+			return recordSyncFailure(ctx, reconciler.Recorder, statusWriter, &monitor, &monitor.Status.Conditions, invalidConfig(err))
+		}
+	}
+
 	monitorObj := urrecon.Monitor{
 		Id:            monitor.Status.Id,
-		AlertContacts: alertContactIds,
+		Http:          monitorHttp,
+		HttpHash:      monitor.Status.HttpSettingsHash,
+		AlertContacts: alertContacts,
 	}
 
-	result, err = urrecon.ReconcileApiObject[urrecon.Monitor](ctx, reconciler, &monitorObj, func() error {
+	var intervalCondition metav1.Condition
+	monitorApiReconciler := urrecon.NewMonitorApiReconciler(apiClient, snapshot)
+	result, err = urrecon.ReconcileApiObject[urrecon.Monitor](ctx, &monitorApiReconciler, &monitorObj, func() error {
 		monitorObj.Name = monitor.Spec.Name
 		monitorObj.Url = monitor.Spec.Url
-		monitorObj.AlertContacts = alertContactIds
-		return nil
+		monitorObj.AlertContacts = alertContacts
+		monitorObj.MaintenanceWindows = maintenanceWindowIds
+		monitorObj.Http = monitorHttp
+		monitorObj.HttpHash = monitorHttp.Hash()
+		intervalCondition = setMonitorSchedule(&monitorObj, monitor.Spec, minimumInterval)
+		monitorObj.Paused = pausedCondition.Status == metav1.ConditionTrue
+		return invalidConfig(setMonitorType(&monitorObj, monitor.Spec, keywordValue))
 	})
 	if err != nil {
-		logger.Error(err, "failed updating monitor on api")
-		return ctrl.Result{}, err
+		if !uptimerobot.IsRateLimited(err) {
+			logger.Error(err, "failed updating monitor on api", "reason", apiErrorReason(err))
+		}
+
+		return recordSyncFailure(ctx, reconciler.Recorder, statusWriter, &monitor, &monitor.Status.Conditions, err)
 	}
 
 	if result != controllerutil.OperationResultNone {
+		missingId := ""
+		if result == controllerutil.OperationResultCreated {
+			missingId = monitor.Status.Id
+		}
+		event := syncEvent{
+			result:    result,
+			drifted:   monitorDrifted(&monitor, monitorObj, resolvedAlertContacts, pausedCondition),
+			adopted:   monitor.Status.LastSyncTime == nil && missingId == "" && result != controllerutil.OperationResultCreated,
+			missingId: missingId,
+			id:        monitorObj.Id,
+		}
+
 		monitor.Status.Id = monitorObj.Id
 		monitor.Status.Name = monitorObj.Name
 		monitor.Status.Url = monitorObj.Url
+		monitorType, err := IntToMonitorType(monitorObj.Type)
+		if err != nil {
+			logger.Error(err, "failed parsing monitor type")
+			return ctrl.Result{}, err
+		}
+		monitor.Status.Type = monitorType
+		monitor.Status.Interval = monitorObj.Interval
+		monitor.Status.HttpSettingsHash = monitorObj.HttpHash
+		monitor.Status.AlertContacts = resolvedAlertContacts
+		monitor.Status.MaintenanceWindows = monitorObj.MaintenanceWindows
+
+		//the heartbeat url, health, uptime and certificate are only known to the api
+		monitors, err := snapshot.Monitors(ctx)
+		if err != nil {
+			if rateLimitedResult, ok := requeueIfRateLimited(ctx, err); ok {
+				return rateLimitedResult, nil
+			}
+			return ctrl.Result{}, err
+		}
+		apiMonitor, ok := monitors[monitorObj.Id]
+		if ok {
+			monitor.Status.HeartbeatUrl = ""
+			if monitorType == uptimerobotcomv1alpha1.HEARTBEAT {
+				monitor.Status.HeartbeatUrl = apiMonitor.Url
+			}
+
+			err = setMonitorHealth(&monitor.Status, apiMonitor)
+			if err != nil {
+				logger.Error(err, "failed parsing monitor status")
+				return ctrl.Result{}, err
+			}
+			setMonitorUptime(&monitor.Status, uptimeRatioPeriods, apiMonitor)
+
+			monitor.Status.SslExpiry = nil
+			monitor.Status.SslIssuer = apiMonitor.SSL.Brand
+			if apiMonitor.SSL.Expires != 0 {
+				sslExpiry := metav1.NewTime(time.Unix(int64(apiMonitor.SSL.Expires), 0))
+				monitor.Status.SslExpiry = &sslExpiry
+			}
+		} else {
+			//the api doesn't list the monitor, e.g. it was deleted since it was synced, which the next reconcile notices
+			logger.Info("monitor missing from the api snapshot", "id", monitorObj.Id)
+			monitor.Status.State = uptimerobotcomv1alpha1.STATE_UNKNOWN
+		}
+
+		monitor.Status.ObservedGeneration = monitor.Generation
+		monitor.Status.LastSyncTime = syncTime(monitor.Status.LastSyncTime, result)
+		setSyncSucceeded(&monitor.Status.Conditions, monitor.Generation, resultReason(result), "")
+		setRemoteMissing(&monitor.Status.Conditions, monitor.Generation, missingId, monitorObj.Id)
+		intervalCondition.ObservedGeneration = monitor.Generation
+		meta.SetStatusCondition(&monitor.Status.Conditions, intervalCondition)
+		pausedCondition.ObservedGeneration = monitor.Generation
+		meta.SetStatusCondition(&monitor.Status.Conditions, pausedCondition)
+		alertRoutesCondition.ObservedGeneration = monitor.Generation
+		meta.SetStatusCondition(&monitor.Status.Conditions, alertRoutesCondition)
+		for _, condition := range []metav1.Condition{intervalCondition, alertRoutesCondition} {
+			if condition.Status == metav1.ConditionFalse {
+				meta.SetStatusCondition(&monitor.Status.Conditions, metav1.Condition{
+					Type:               uptimerobotcomv1alpha1.CONDITION_DEGRADED,
+					Status:             metav1.ConditionTrue,
+					Reason:             condition.Reason,
+					Message:            condition.Message,
+					ObservedGeneration: monitor.Generation,
+				})
+			}
+		}
+
+		recordSyncEvent(reconciler.Recorder, &monitor, event)
+		if intervalCondition.Status == metav1.ConditionFalse && event.result != controllerutil.OperationResultUpdatedStatus {
+			reconciler.Recorder.Event(&monitor, corev1.EventTypeWarning, intervalCondition.Reason, intervalCondition.Message)
+		}
 
-		statusClient := reconciler.Client.Status()
-		err = statusClient.Update(ctx, &monitor)
+		err = updateStatusIfChanged(ctx, statusWriter, &monitor, originalStatus, &monitor.Status)
 		if err != nil {
 			logger.Error(err, "failed updating status")
 			return ctrl.Result{}, err
@@ -172,9 +979,206 @@ func (reconciler *MonitorReconciler) Reconcile(ctx context.Context, request ctrl
 	}, nil
 }
 
+// monitorSecretNames lists the Secrets a Monitor reads values from
+func monitorSecretNames(monitor *uptimerobotcomv1alpha1.Monitor) []string {
+	var values []uptimerobotcomv1alpha1.ValueOrSecret
+	if monitor.Spec.Keyword != nil {
+		values = append(values, monitor.Spec.Keyword.ValueOrSecret)
+	}
+
+	if monitor.Spec.Http != nil {
+		for _, header := range monitor.Spec.Http.Headers {
+			values = append(values, header.ValueOrSecret)
+		}
+
+		if monitor.Spec.Http.Auth != nil {
+			values = append(values, monitor.Spec.Http.Auth.Username, uptimerobotcomv1alpha1.ValueOrSecret{
+				ValueFrom: &monitor.Spec.Http.Auth.Password.ValueFrom,
+			})
+		}
+	}
+
+	var names []string
+	for _, value := range values {
+		if value.ValueFrom != nil {
+			names = append(names, value.ValueFrom.SecretKeyRef.Name)
+		}
+	}
+
+	return names
+}
+
+// monitorsForSecret maps a Secret to the Monitors in its namespace that read values from it,
+// so rotating a credential edits the monitor on UptimeRobot
+func (r *MonitorReconciler) monitorsForSecret(ctx context.Context, secret client.Object) []reconcile.Request {
+	logger := log.FromContext(ctx)
+	monitors := uptimerobotcomv1alpha1.MonitorList{}
+	err := r.List(ctx, &monitors, client.InNamespace(secret.GetNamespace()))
+	if err != nil {
+		logger.Error(err, "failed to list monitors for secret", "secret", secret.GetName())
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, monitor := range monitors.Items {
+		for _, name := range monitorSecretNames(&monitor) {
+			if name == secret.GetName() {
+				requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&monitor)})
+				break
+			}
+		}
+	}
+
+	return requests
+}
+
+// monitorsForAccount maps an Account to the Monitors that reference it, so pausing the account
+// pauses its monitors
+func (r *MonitorReconciler) monitorsForAccount(ctx context.Context, account client.Object) []reconcile.Request {
+	logger := log.FromContext(ctx)
+	monitors := uptimerobotcomv1alpha1.MonitorList{}
+	err := r.List(ctx, &monitors, client.InNamespace(account.GetNamespace()))
+	if err != nil {
+		logger.Error(err, "failed to list monitors for account", "account", account.GetName())
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, monitor := range monitors.Items {
+		if monitor.Spec.AccountRef != nil && monitor.Spec.AccountRef.Name == account.GetName() {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&monitor)})
+		}
+	}
+
+	return requests
+}
+
+// monitorsForNamespace maps a Namespace to every Monitor in it, so annotating the namespace pauses them
+func (r *MonitorReconciler) monitorsForNamespace(ctx context.Context, namespace client.Object) []reconcile.Request {
+	logger := log.FromContext(ctx)
+	monitors := uptimerobotcomv1alpha1.MonitorList{}
+	err := r.List(ctx, &monitors, client.InNamespace(namespace.GetName()))
+	if err != nil {
+		logger.Error(err, "failed to list monitors for namespace", "namespace", namespace.GetName())
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(monitors.Items))
+	for _, monitor := range monitors.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&monitor)})
+	}
+
+	return requests
+}
+
+// monitorsForMaintenanceWindow maps a MaintenanceWindow to the Monitors in its namespace that select it,
+// so a window is attached once it has an id
+func (r *MonitorReconciler) monitorsForMaintenanceWindow(ctx context.Context, mwindow client.Object) []reconcile.Request {
+	logger := log.FromContext(ctx)
+	monitors := uptimerobotcomv1alpha1.MonitorList{}
+	err := r.List(ctx, &monitors, client.InNamespace(mwindow.GetNamespace()))
+	if err != nil {
+		logger.Error(err, "failed to list monitors for maintenance window", "maintenanceWindow", mwindow.GetName())
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, monitor := range monitors.Items {
+		selector, err := metav1.LabelSelectorAsSelector(&monitor.Spec.MaintenanceWindows)
+		if err != nil || selector.Empty() {
+			continue
+		}
+
+		if selector.Matches(labels.Set(mwindow.GetLabels())) {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&monitor)})
+		}
+	}
+
+	return requests
+}
+
+// monitorsForAlertContact maps an AlertContact to the Monitors that select it, in its namespace or through
+// alertContactNamespaces, so a contact is attached once it has an id
+func (r *MonitorReconciler) monitorsForAlertContact(ctx context.Context, alertContact client.Object) []reconcile.Request {
+	logger := log.FromContext(ctx)
+	monitors := uptimerobotcomv1alpha1.MonitorList{}
+	err := r.List(ctx, &monitors)
+	if err != nil {
+		logger.Error(err, "failed to list monitors for alert contact", "alertContact", alertContact.GetName())
+		return nil
+	}
+
+	var namespace *corev1.Namespace
+	var requests []reconcile.Request
+	for _, monitor := range monitors.Items {
+		if monitor.Namespace != alertContact.GetNamespace() {
+			if monitor.Spec.AlertContactNamespaces == nil {
+				continue
+			}
+
+			namespaceSelector, err := metav1.LabelSelectorAsSelector(monitor.Spec.AlertContactNamespaces)
+			if err != nil {
+				continue
+			}
+
+			if namespace == nil {
+				namespace = &corev1.Namespace{}
+				err = r.Get(ctx, types.NamespacedName{Name: alertContact.GetNamespace()}, namespace)
+				if err != nil {
+					logger.Error(err, "failed to get namespace of alert contact", "alertContact", alertContact.GetName())
+					return requests
+				}
+			}
+
+			if !namespaceSelector.Matches(labels.Set(namespace.GetLabels())) {
+				continue
+			}
+		}
+
+		labelSelectors := []metav1.LabelSelector{monitor.Spec.AlertContacts}
+		for _, route := range monitor.Spec.AlertRoutes {
+			labelSelectors = append(labelSelectors, route.AlertContacts)
+		}
+
+		for _, labelSelector := range labelSelectors {
+			selector, err := metav1.LabelSelectorAsSelector(&labelSelector)
+			if err == nil && selector.Matches(labels.Set(alertContact.GetLabels())) {
+				requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&monitor)})
+				break
+			}
+		}
+	}
+
+	return requests
+}
+
+// accountSyncedStatus is the part of an Account's status its monitors are synced from
+func accountSyncedStatus(object client.Object) interface{} {
+	account := object.(*uptimerobotcomv1alpha1.Account)
+	return []interface{}{account.Status.Email, account.Status.MonitorInterval, account.Status.AlertThresholdsSupported}
+}
+
+// alertContactId is the id an AlertContact's monitors route their alerts to
+func alertContactId(object client.Object) interface{} {
+	return object.(*uptimerobotcomv1alpha1.AlertContact).Status.Id
+}
+
+// maintenanceWindowId is the id a MaintenanceWindow's monitors are attached to
+func maintenanceWindowId(object client.Object) interface{} {
+	return object.(*uptimerobotcomv1alpha1.MaintenanceWindow).Status.Id
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *MonitorReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&uptimerobotcomv1alpha1.Monitor{}).
+		For(&uptimerobotcomv1alpha1.Monitor{}, builder.WithPredicates(specChanged)).
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(r.monitorsForSecret)).
+		Watches(&uptimerobotcomv1alpha1.Account{}, handler.EnqueueRequestsFromMapFunc(r.monitorsForAccount),
+			builder.WithPredicates(resourceChanged(accountSyncedStatus))).
+		Watches(&uptimerobotcomv1alpha1.AlertContact{}, handler.EnqueueRequestsFromMapFunc(r.monitorsForAlertContact),
+			builder.WithPredicates(resourceChanged(alertContactId))).
+		Watches(&uptimerobotcomv1alpha1.MaintenanceWindow{}, handler.EnqueueRequestsFromMapFunc(r.monitorsForMaintenanceWindow),
+			builder.WithPredicates(resourceChanged(maintenanceWindowId))).
+		Watches(&corev1.Namespace{}, handler.EnqueueRequestsFromMapFunc(r.monitorsForNamespace)).
 		Complete(r)
 }
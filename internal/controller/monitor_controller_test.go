@@ -0,0 +1,631 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	uptimerobotcomv1alpha1 "github.com/luckielordie/uptime-robot-operator/api/v1alpha1"
+	"github.com/luckielordie/uptime-robot-operator/internal/controller/urrecon"
+	"github.com/luckielordie/uptime-robot-operator/internal/uptimerobot"
+)
+
+func newTestReader(t *testing.T, objects ...client.Object) client.Client {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	if err := corev1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to build scheme: %v", err)
+	}
+	if err := uptimerobotcomv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to build scheme: %v", err)
+	}
+
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+}
+
+// newTestApiClient returns a client for a server answering every request with body, and the forms it was sent
+func newTestApiClient(t *testing.T, body string) (*uptimerobot.Client, *[]url.Values) {
+	t.Helper()
+	var forms []url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if err := request.ParseForm(); err != nil {
+			t.Errorf("failed to parse form: %v", err)
+		}
+		forms = append(forms, request.PostForm)
+		writer.Header().Set("content-type", "application/json")
+		fmt.Fprint(writer, body)
+	}))
+	t.Cleanup(server.Close)
+
+	apiClient, err := uptimerobot.NewClient("key", uptimerobot.WithBaseUrl(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	return apiClient, &forms
+}
+
+func secretRef(name string, key string) *uptimerobotcomv1alpha1.ValueFromSource {
+	return &uptimerobotcomv1alpha1.ValueFromSource{
+		SecretKeyRef: corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: name}, Key: key},
+	}
+}
+
+func TestRemovedSecretHttpSettingsAreClearedRemotely(t *testing.T) {
+	reader := newTestReader(t, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "credentials"},
+		Data:       map[string][]byte{"token": []byte("Bearer secret"), "password": []byte("hunter2")},
+	})
+
+	withSecrets := &uptimerobotcomv1alpha1.HttpConfig{
+		Headers: []uptimerobotcomv1alpha1.HttpHeader{
+			{Name: "Authorization", ValueOrSecret: uptimerobotcomv1alpha1.ValueOrSecret{ValueFrom: secretRef("credentials", "token")}},
+		},
+		Auth: &uptimerobotcomv1alpha1.HttpAuth{
+			Username: uptimerobotcomv1alpha1.ValueOrSecret{Value: "monitor"},
+			Password: uptimerobotcomv1alpha1.SecretValue{ValueFrom: *secretRef("credentials", "password")},
+		},
+	}
+
+	testCases := []struct {
+		name   string
+		remove func(spec *uptimerobotcomv1alpha1.HttpConfig)
+		param  string
+		reset  string
+	}{
+		{"header", func(spec *uptimerobotcomv1alpha1.HttpConfig) { spec.Headers = nil }, "custom_http_headers", "{}"},
+		{"password", func(spec *uptimerobotcomv1alpha1.HttpConfig) { spec.Auth = nil }, "http_password", ""},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctx := context.Background()
+			before, err := getMonitorHttp(ctx, reader, "default", withSecrets)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			spec := withSecrets.DeepCopy()
+			testCase.remove(spec)
+			after, err := getMonitorHttp(ctx, reader, "default", spec)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if before.Hash() == after.Hash() {
+				t.Fatalf("expected removing the %s to change the settings hash", testCase.name)
+			}
+
+			apiClient, forms := newTestApiClient(t, `{"stat":"ok","monitor":{"id":1}}`)
+			apiReconciler := urrecon.NewMonitorApiReconciler(apiClient, urrecon.NewSnapshotCache(apiClient, time.Minute))
+			err = apiReconciler.EditApiObject(ctx, &urrecon.Monitor{Id: "1", Type: uptimerobot.MonitorTypeHttp, Http: after, HttpHash: after.Hash()})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			form := (*forms)[0]
+			if !form.Has(testCase.param) || form.Get(testCase.param) != testCase.reset {
+				t.Errorf("expected %s to be reset to %q, got %q", testCase.param, testCase.reset, form.Get(testCase.param))
+			}
+		})
+	}
+}
+
+func TestLabelCheckingClientReportsUnlabelledSecrets(t *testing.T) {
+	unlabelled := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "unlabelled"},
+		Data:       map[string][]byte{"password": []byte("secret")},
+	}
+	reader := NewLabelCheckingClient(newTestReader(t), newTestReader(t, unlabelled))
+	selector := corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "unlabelled"}, Key: "password"}
+
+	_, err := getSecretKeyValue(context.Background(), reader, "default", selector)
+	if !isInvalidConfig(err) || !strings.Contains(err.Error(), uptimerobotcomv1alpha1.LABEL_SECRET) {
+		t.Errorf("expected an error naming the missing label, got %v", err)
+	}
+
+	selector.Name = "missing"
+	_, err = getSecretKeyValue(context.Background(), reader, "default", selector)
+	if !apierrors.IsNotFound(err) {
+		t.Errorf("expected a missing secret to be not found, got %v", err)
+	}
+}
+
+func TestDeletingMonitorOfRemovedAccountReleasesFinalizer(t *testing.T) {
+	now := metav1.Now()
+	monitor := &uptimerobotcomv1alpha1.Monitor{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:         "default",
+			Name:              "web",
+			Finalizers:        []string{FINALIZER_TOKEN},
+			DeletionTimestamp: &now,
+		},
+		Spec:   uptimerobotcomv1alpha1.MonitorSpec{AccountRef: &corev1.LocalObjectReference{Name: "removed"}},
+		Status: uptimerobotcomv1alpha1.MonitorStatus{Id: "1"},
+	}
+	reader := newTestReader(t, monitor)
+	recorder := record.NewFakeRecorder(1)
+	reconciler := &MonitorReconciler{
+		Client:    reader,
+		Clients:   uptimerobot.NewClientPool(nil),
+		Snapshots: urrecon.NewSnapshotCaches(time.Minute),
+		Recorder:  recorder,
+	}
+
+	_, err := reconciler.Reconcile(context.Background(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(monitor)})
+	if err != nil {
+		t.Fatalf("expected the deletion to go through, got %v", err)
+	}
+
+	err = reader.Get(context.Background(), client.ObjectKeyFromObject(monitor), &uptimerobotcomv1alpha1.Monitor{})
+	if !apierrors.IsNotFound(err) {
+		t.Errorf("expected the monitor to be gone, got %v", err)
+	}
+	if len(recorder.Events) != 1 {
+		t.Errorf("expected a warning about the monitor left on UptimeRobot")
+	}
+}
+
+func TestMonitorDrifted(t *testing.T) {
+	alertContacts := []uptimerobotcomv1alpha1.ResolvedAlertContact{{Namespace: "default", Name: "ops", Id: "2"}}
+	paused := metav1.Condition{Type: uptimerobotcomv1alpha1.CONDITION_PAUSED, Status: metav1.ConditionFalse}
+	lastSent := urrecon.Monitor{Interval: 300, HttpHash: "hash", MaintenanceWindows: []string{"7"}}
+	monitor := &uptimerobotcomv1alpha1.Monitor{
+		ObjectMeta: metav1.ObjectMeta{Generation: 3},
+		Status: uptimerobotcomv1alpha1.MonitorStatus{
+			ObservedGeneration: 3,
+			Interval:           300,
+			HttpSettingsHash:   "hash",
+			AlertContacts:      alertContacts,
+			MaintenanceWindows: []string{"7"},
+			Conditions:         []metav1.Condition{paused},
+		},
+	}
+
+	testCases := []struct {
+		name          string
+		change        func(monitorObj *urrecon.Monitor, alertContacts *[]uptimerobotcomv1alpha1.ResolvedAlertContact, monitor *uptimerobotcomv1alpha1.Monitor)
+		expectedDrift bool
+	}{
+		{"nothing sent changed", func(*urrecon.Monitor, *[]uptimerobotcomv1alpha1.ResolvedAlertContact, *uptimerobotcomv1alpha1.Monitor) {
+		}, true},
+		{"spec changed", func(_ *urrecon.Monitor, _ *[]uptimerobotcomv1alpha1.ResolvedAlertContact, monitor *uptimerobotcomv1alpha1.Monitor) {
+			monitor.Generation++
+		}, false},
+		{"secret rotated", func(monitorObj *urrecon.Monitor, _ *[]uptimerobotcomv1alpha1.ResolvedAlertContact, _ *uptimerobotcomv1alpha1.Monitor) {
+			monitorObj.HttpHash = "rotated"
+		}, false},
+		{"alert contact selected", func(_ *urrecon.Monitor, alertContacts *[]uptimerobotcomv1alpha1.ResolvedAlertContact, _ *uptimerobotcomv1alpha1.Monitor) {
+			*alertContacts = append(*alertContacts, uptimerobotcomv1alpha1.ResolvedAlertContact{Namespace: "default", Name: "pager", Id: "3"})
+		}, false},
+		{"maintenance window selected", func(monitorObj *urrecon.Monitor, _ *[]uptimerobotcomv1alpha1.ResolvedAlertContact, _ *uptimerobotcomv1alpha1.Monitor) {
+			monitorObj.MaintenanceWindows = []string{"7", "8"}
+		}, false},
+		{"minimum interval raised", func(monitorObj *urrecon.Monitor, _ *[]uptimerobotcomv1alpha1.ResolvedAlertContact, _ *uptimerobotcomv1alpha1.Monitor) {
+			monitorObj.Interval = 600
+		}, false},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			monitor := monitor.DeepCopy()
+			monitorObj := lastSent
+			monitorObj.MaintenanceWindows = append([]string{}, lastSent.MaintenanceWindows...)
+			resolved := append([]uptimerobotcomv1alpha1.ResolvedAlertContact{}, alertContacts...)
+			testCase.change(&monitorObj, &resolved, monitor)
+
+			if drifted := monitorDrifted(monitor, monitorObj, resolved, paused); drifted != testCase.expectedDrift {
+				t.Errorf("expected drifted to be %t, got %t", testCase.expectedDrift, drifted)
+			}
+		})
+	}
+}
+
+func TestSelectionOnlyPicksResourcesOfTheSameAccount(t *testing.T) {
+	team := &corev1.LocalObjectReference{Name: "team"}
+	shared := &corev1.LocalObjectReference{Name: "shared"}
+	labels := map[string]string{"team": "web"}
+	meta := func(namespace string, name string) metav1.ObjectMeta {
+		return metav1.ObjectMeta{Namespace: namespace, Name: name, Labels: labels}
+	}
+	account := func(namespace string, name string, email string) *uptimerobotcomv1alpha1.Account {
+		return &uptimerobotcomv1alpha1.Account{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+			Status:     uptimerobotcomv1alpha1.AccountStatus{Email: email},
+		}
+	}
+	alertContact := func(namespace string, name string, id string, accountRef *corev1.LocalObjectReference) *uptimerobotcomv1alpha1.AlertContact {
+		return &uptimerobotcomv1alpha1.AlertContact{
+			ObjectMeta: meta(namespace, name),
+			Spec:       uptimerobotcomv1alpha1.AlertContactSpec{AccountRef: accountRef},
+			Status:     uptimerobotcomv1alpha1.AlertContactStatus{Id: id},
+		}
+	}
+
+	reader := newTestReader(t,
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "oncall", Labels: map[string]string{"oncall": "true"}}},
+		account("default", "team", "team@example.com"),
+		account("oncall", "shared", "team@example.com"),
+		account("oncall", "other", "other@example.com"),
+		alertContact("default", "team", "1", team),
+		alertContact("default", "default", "2", nil),
+		alertContact("oncall", "shared", "3", shared),
+		alertContact("oncall", "other", "4", &corev1.LocalObjectReference{Name: "other"}),
+		alertContact("oncall", "default", "5", nil),
+		&uptimerobotcomv1alpha1.MaintenanceWindow{
+			ObjectMeta: meta("default", "team"),
+			Spec:       uptimerobotcomv1alpha1.MaintenanceWindowSpec{AccountRef: team},
+			Status:     uptimerobotcomv1alpha1.MaintenanceWindowStatus{Id: "6"},
+		},
+		&uptimerobotcomv1alpha1.MaintenanceWindow{ObjectMeta: meta("default", "default"), Status: uptimerobotcomv1alpha1.MaintenanceWindowStatus{Id: "7"}},
+		&uptimerobotcomv1alpha1.Monitor{
+			ObjectMeta: meta("default", "team"),
+			Spec:       uptimerobotcomv1alpha1.MonitorSpec{AccountRef: team},
+			Status:     uptimerobotcomv1alpha1.MonitorStatus{Id: "8"},
+		},
+		&uptimerobotcomv1alpha1.Monitor{ObjectMeta: meta("default", "default"), Status: uptimerobotcomv1alpha1.MonitorStatus{Id: "9"}},
+	)
+
+	selector := metav1.LabelSelector{MatchLabels: labels}
+	testCases := []struct {
+		name                       string
+		accountRef                 *corev1.LocalObjectReference
+		account                    *uptimerobotcomv1alpha1.Account
+		expectedAlertContacts      []string
+		expectedMaintenanceWindows []string
+		expectedMonitors           []string
+	}{
+		{"account", team, account("default", "team", "team@example.com"), []string{"1_0_0", "3_0_0"}, []string{"6"}, []string{"8"}},
+		{"default account", nil, nil, []string{"2_0_0", "5_0_0"}, []string{"7"}, []string{"9"}},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctx := context.Background()
+			monitor := &uptimerobotcomv1alpha1.Monitor{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web"},
+				Spec: uptimerobotcomv1alpha1.MonitorSpec{
+					AccountRef:             testCase.accountRef,
+					AlertContacts:          selector,
+					AlertContactNamespaces: &metav1.LabelSelector{MatchLabels: map[string]string{"oncall": "true"}},
+				},
+			}
+
+			alertContacts, _, err := getMonitorAlertContacts(ctx, reader, monitor, testCase.account)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if fmt.Sprint(alertContacts) != fmt.Sprint(testCase.expectedAlertContacts) {
+				t.Errorf("expected alert contacts %v, got %v", testCase.expectedAlertContacts, alertContacts)
+			}
+
+			mwindows, err := getListOfMaintenanceWindowIds(ctx, reader, "default", testCase.accountRef, selector)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if fmt.Sprint(mwindows) != fmt.Sprint(testCase.expectedMaintenanceWindows) {
+				t.Errorf("expected maintenance windows %v, got %v", testCase.expectedMaintenanceWindows, mwindows)
+			}
+
+			monitors, err := getListOfMonitorIds(ctx, reader, "default", testCase.accountRef, selector)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if fmt.Sprint(monitors) != fmt.Sprint(testCase.expectedMonitors) {
+				t.Errorf("expected monitors %v, got %v", testCase.expectedMonitors, monitors)
+			}
+		})
+	}
+}
+
+func TestGetMonitorAlertContacts(t *testing.T) {
+	alertContact := func(name string, id string, labels map[string]string) *uptimerobotcomv1alpha1.AlertContact {
+		return &uptimerobotcomv1alpha1.AlertContact{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name, Labels: labels},
+			Status:     uptimerobotcomv1alpha1.AlertContactStatus{Id: id},
+		}
+	}
+	reader := newTestReader(t,
+		alertContact("email", "1", map[string]string{"team": "web", "channel": "email"}),
+		alertContact("pager", "2", map[string]string{"team": "web", "channel": "pager"}),
+		alertContact("sms", "3", map[string]string{"team": "web", "channel": "sms"}),
+		alertContact("ops", "4", map[string]string{"team": "ops", "channel": "email"}),
+		alertContact("unsynced", "", map[string]string{"team": "web", "channel": "email"}),
+	)
+	selector := func(labels map[string]string) metav1.LabelSelector {
+		return metav1.LabelSelector{MatchLabels: labels}
+	}
+	route := func(labels map[string]string, threshold int, recurrence int) uptimerobotcomv1alpha1.AlertRoute {
+		return uptimerobotcomv1alpha1.AlertRoute{AlertContacts: selector(labels), Threshold: threshold, Recurrence: recurrence}
+	}
+
+	testCases := []struct {
+		name     string
+		spec     uptimerobotcomv1alpha1.MonitorSpec
+		expected []string
+	}{
+		{
+			name:     "contacts are notified straight away",
+			spec:     uptimerobotcomv1alpha1.MonitorSpec{AlertContacts: selector(map[string]string{"team": "web"})},
+			expected: []string{"1_0_0", "2_0_0", "3_0_0"},
+		},
+		{
+			name: "routes alone leave out the contacts they don't select",
+			spec: uptimerobotcomv1alpha1.MonitorSpec{AlertRoutes: []uptimerobotcomv1alpha1.AlertRoute{
+				route(map[string]string{"channel": "pager"}, 5, 30),
+			}},
+			expected: []string{"2_5_30"},
+		},
+		{
+			name: "the first route selecting a contact wins",
+			spec: uptimerobotcomv1alpha1.MonitorSpec{AlertRoutes: []uptimerobotcomv1alpha1.AlertRoute{
+				route(map[string]string{"channel": "pager"}, 5, 30),
+				route(map[string]string{"team": "web"}, 15, 0),
+			}},
+			expected: []string{"1_15_0", "2_5_30", "3_15_0"},
+		},
+		{
+			name: "routes take precedence over alertContacts",
+			spec: uptimerobotcomv1alpha1.MonitorSpec{
+				AlertContacts: selector(map[string]string{"channel": "email"}),
+				AlertRoutes: []uptimerobotcomv1alpha1.AlertRoute{
+					route(map[string]string{"team": "web", "channel": "email"}, 10, 60),
+				},
+			},
+			expected: []string{"1_10_60", "4_0_0"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			monitor := &uptimerobotcomv1alpha1.Monitor{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web"},
+				Spec:       testCase.spec,
+			}
+
+			alertContacts, resolved, err := getMonitorAlertContacts(context.Background(), reader, monitor, nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if fmt.Sprint(alertContacts) != fmt.Sprint(testCase.expected) {
+				t.Errorf("expected %v, got %v", testCase.expected, alertContacts)
+			}
+			if len(resolved) != len(alertContacts) {
+				t.Errorf("expected %d resolved contacts, got %d", len(alertContacts), len(resolved))
+			}
+		})
+	}
+}
+
+func TestNormalizeAlertRoutes(t *testing.T) {
+	routed := []uptimerobotcomv1alpha1.ResolvedAlertContact{
+		{Namespace: "default", Name: "pager", Id: "12", Threshold: 5, Recurrence: 30},
+		{Namespace: "default", Name: "email", Id: "3"},
+	}
+	alertContacts := []string{"12_5_30", "3_0_0"}
+
+	testCases := []struct {
+		name           string
+		alertContacts  []string
+		resolved       []uptimerobotcomv1alpha1.ResolvedAlertContact
+		supported      bool
+		expected       []string
+		expectedStatus metav1.ConditionStatus
+	}{
+		{"plan stores routes", alertContacts, routed, true, alertContacts, metav1.ConditionTrue},
+		{"plan drops routes", alertContacts, routed, false, []string{"12_0_0", "3_0_0"}, metav1.ConditionFalse},
+		{"no routes on a plan without them", []string{"3_0_0"}, routed[1:], false, []string{"3_0_0"}, metav1.ConditionTrue},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			normalized, condition := normalizeAlertRoutes(testCase.alertContacts, testCase.resolved, testCase.supported)
+
+			if fmt.Sprint(normalized) != fmt.Sprint(testCase.expected) {
+				t.Errorf("expected %v, got %v", testCase.expected, normalized)
+			}
+			if condition.Status != testCase.expectedStatus {
+				t.Errorf("expected condition status %s, got %s", testCase.expectedStatus, condition.Status)
+			}
+		})
+	}
+}
+
+func TestEncodeHttpStatuses(t *testing.T) {
+	testCases := []struct {
+		name     string
+		statuses uptimerobotcomv1alpha1.HttpStatuses
+		expected string
+	}{
+		{"none", uptimerobotcomv1alpha1.HttpStatuses{}, ""},
+		{"sorted by code", uptimerobotcomv1alpha1.HttpStatuses{Up: []uptimerobotcomv1alpha1.HttpStatusCode{404, 200}, Down: []uptimerobotcomv1alpha1.HttpStatusCode{302}}, "200:1_302:0_404:1"},
+		{"down wins over up", uptimerobotcomv1alpha1.HttpStatuses{Up: []uptimerobotcomv1alpha1.HttpStatusCode{503}, Down: []uptimerobotcomv1alpha1.HttpStatusCode{503}}, "503:0"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			if encoded := encodeHttpStatuses(testCase.statuses); encoded != testCase.expected {
+				t.Errorf("expected %q, got %q", testCase.expected, encoded)
+			}
+		})
+	}
+}
+
+func TestSetMonitorSchedule(t *testing.T) {
+	testCases := []struct {
+		name             string
+		spec             uptimerobotcomv1alpha1.MonitorSpec
+		minimumInterval  int
+		expectedInterval int
+		expectedTimeout  int
+		expectedStatus   metav1.ConditionStatus
+	}{
+		{"defaults", uptimerobotcomv1alpha1.MonitorSpec{Type: uptimerobotcomv1alpha1.HTTP}, 0, DEFAULT_MONITOR_INTERVAL, DEFAULT_MONITOR_TIMEOUT, metav1.ConditionTrue},
+		{"as specified", uptimerobotcomv1alpha1.MonitorSpec{Type: uptimerobotcomv1alpha1.KEYWORD, Interval: 120, Timeout: 15}, 60, 120, 15, metav1.ConditionTrue},
+		{"raised to the account's minimum", uptimerobotcomv1alpha1.MonitorSpec{Type: uptimerobotcomv1alpha1.HTTP, Interval: 60}, 300, 300, DEFAULT_MONITOR_TIMEOUT, metav1.ConditionFalse},
+		{"ping has no timeout", uptimerobotcomv1alpha1.MonitorSpec{Type: uptimerobotcomv1alpha1.PING, Timeout: 15}, 0, DEFAULT_MONITOR_INTERVAL, 0, metav1.ConditionTrue},
+		{"heartbeat has no timeout", uptimerobotcomv1alpha1.MonitorSpec{Type: uptimerobotcomv1alpha1.HEARTBEAT, Interval: 600}, 300, 600, 0, metav1.ConditionTrue},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			monitorObj := urrecon.Monitor{Timeout: 45}
+			condition := setMonitorSchedule(&monitorObj, testCase.spec, testCase.minimumInterval)
+
+			if monitorObj.Interval != testCase.expectedInterval {
+				t.Errorf("expected an interval of %d, got %d", testCase.expectedInterval, monitorObj.Interval)
+			}
+			if monitorObj.Timeout != testCase.expectedTimeout {
+				t.Errorf("expected a timeout of %d, got %d", testCase.expectedTimeout, monitorObj.Timeout)
+			}
+			if condition.Status != testCase.expectedStatus {
+				t.Errorf("expected condition status %s, got %s", testCase.expectedStatus, condition.Status)
+			}
+		})
+	}
+}
+
+func TestGetMonitorPause(t *testing.T) {
+	paused := map[string]string{uptimerobotcomv1alpha1.ANNOTATION_PAUSED: "true"}
+	pausingAccount := &uptimerobotcomv1alpha1.Account{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "team"},
+		Spec:       uptimerobotcomv1alpha1.AccountSpec{PauseMonitors: true},
+	}
+
+	testCases := []struct {
+		name                 string
+		namespaceAnnotations map[string]string
+		monitorAnnotations   map[string]string
+		specPaused           bool
+		account              *uptimerobotcomv1alpha1.Account
+		expectedStatus       metav1.ConditionStatus
+		expectedReason       string
+	}{
+		{"monitoring", nil, nil, false, nil, metav1.ConditionFalse, uptimerobotcomv1alpha1.REASON_NOT_PAUSED},
+		{"by spec", nil, nil, true, nil, metav1.ConditionTrue, uptimerobotcomv1alpha1.REASON_PAUSED_BY_SPEC},
+		{"by annotation", nil, paused, false, nil, metav1.ConditionTrue, uptimerobotcomv1alpha1.REASON_PAUSED_BY_ANNOTATION},
+		{"by account", nil, nil, false, pausingAccount, metav1.ConditionTrue, uptimerobotcomv1alpha1.REASON_PAUSED_BY_ACCOUNT},
+		{"by namespace", paused, nil, false, nil, metav1.ConditionTrue, uptimerobotcomv1alpha1.REASON_PAUSED_BY_NAMESPACE},
+		{"spec before namespace", paused, nil, true, nil, metav1.ConditionTrue, uptimerobotcomv1alpha1.REASON_PAUSED_BY_SPEC},
+		{"ignores other annotation values", nil, map[string]string{uptimerobotcomv1alpha1.ANNOTATION_PAUSED: "false"}, false, nil, metav1.ConditionFalse, uptimerobotcomv1alpha1.REASON_NOT_PAUSED},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			reader := newTestReader(t, &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{Name: "default", Annotations: testCase.namespaceAnnotations},
+			})
+			monitor := &uptimerobotcomv1alpha1.Monitor{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web", Annotations: testCase.monitorAnnotations},
+				Spec:       uptimerobotcomv1alpha1.MonitorSpec{Paused: testCase.specPaused},
+			}
+
+			condition, err := getMonitorPause(context.Background(), reader, monitor, testCase.account)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if condition.Status != testCase.expectedStatus || condition.Reason != testCase.expectedReason {
+				t.Errorf("expected %s/%s, got %s/%s", testCase.expectedStatus, testCase.expectedReason, condition.Status, condition.Reason)
+			}
+		})
+	}
+}
+
+func TestSetMonitorType(t *testing.T) {
+	keyword := func(keywordType uptimerobotcomv1alpha1.KeywordType, caseSensitive bool) *uptimerobotcomv1alpha1.KeywordConfig {
+		return &uptimerobotcomv1alpha1.KeywordConfig{Type: keywordType, CaseSensitive: caseSensitive}
+	}
+
+	testCases := []struct {
+		name     string
+		spec     uptimerobotcomv1alpha1.MonitorSpec
+		expected urrecon.Monitor
+	}{
+		{
+			name:     "http by default",
+			spec:     uptimerobotcomv1alpha1.MonitorSpec{},
+			expected: urrecon.Monitor{Type: 1},
+		},
+		{
+			name:     "heartbeat",
+			spec:     uptimerobotcomv1alpha1.MonitorSpec{Type: uptimerobotcomv1alpha1.HEARTBEAT},
+			expected: urrecon.Monitor{Type: 5},
+		},
+		{
+			name:     "well known port",
+			spec:     uptimerobotcomv1alpha1.MonitorSpec{Type: uptimerobotcomv1alpha1.PORT, Port: &uptimerobotcomv1alpha1.PortConfig{SubType: uptimerobotcomv1alpha1.PORT_SMTP}},
+			expected: urrecon.Monitor{Type: 4, SubType: 4},
+		},
+		{
+			name:     "custom port",
+			spec:     uptimerobotcomv1alpha1.MonitorSpec{Type: uptimerobotcomv1alpha1.PORT, Port: &uptimerobotcomv1alpha1.PortConfig{SubType: uptimerobotcomv1alpha1.PORT_CUSTOM, Port: 8443}},
+			expected: urrecon.Monitor{Type: 4, SubType: 99, Port: 8443},
+		},
+		{
+			name:     "keyword exists case insensitively",
+			spec:     uptimerobotcomv1alpha1.MonitorSpec{Type: uptimerobotcomv1alpha1.KEYWORD, Keyword: keyword(uptimerobotcomv1alpha1.KEYWORD_EXISTS, false)},
+			expected: urrecon.Monitor{Type: 2, KeywordType: 1, KeywordCaseType: 1, KeywordValue: "healthy"},
+		},
+		{
+			name:     "keyword doesn't exist case sensitively",
+			spec:     uptimerobotcomv1alpha1.MonitorSpec{Type: uptimerobotcomv1alpha1.KEYWORD, Keyword: keyword(uptimerobotcomv1alpha1.KEYWORD_NOT_EXISTS, true)},
+			expected: urrecon.Monitor{Type: 2, KeywordType: 2, KeywordValue: "healthy"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			//settings of a previous type are cleared
+			monitorObj := urrecon.Monitor{SubType: 99, Port: 22, KeywordType: 2, KeywordCaseType: 1, KeywordValue: "stale"}
+			keywordValue := ""
+			if testCase.spec.Keyword != nil {
+				keywordValue = "healthy"
+			}
+
+			err := setMonitorType(&monitorObj, testCase.spec, keywordValue)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if fmt.Sprintf("%+v", monitorObj) != fmt.Sprintf("%+v", testCase.expected) {
+				t.Errorf("expected %+v, got %+v", testCase.expected, monitorObj)
+			}
+		})
+	}
+}
+
+func TestSetMonitorTypeRejectsUnknownTypes(t *testing.T) {
+	testCases := []struct {
+		name string
+		spec uptimerobotcomv1alpha1.MonitorSpec
+	}{
+		{"monitor type", uptimerobotcomv1alpha1.MonitorSpec{Type: "dns"}},
+		{"port sub type", uptimerobotcomv1alpha1.MonitorSpec{Type: uptimerobotcomv1alpha1.PORT, Port: &uptimerobotcomv1alpha1.PortConfig{SubType: "ssh"}}},
+		{"keyword type", uptimerobotcomv1alpha1.MonitorSpec{Type: uptimerobotcomv1alpha1.KEYWORD, Keyword: &uptimerobotcomv1alpha1.KeywordConfig{Type: "contains"}}},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := setMonitorType(&urrecon.Monitor{}, testCase.spec, "")
+			if err == nil {
+				t.Errorf("expected an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,350 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	uptimerobotcomv1alpha1 "github.com/luckielordie/uptime-robot-operator/api/v1alpha1"
+	"github.com/luckielordie/uptime-robot-operator/internal/controller/urrecon"
+	"github.com/luckielordie/uptime-robot-operator/internal/uptimerobot"
+)
+
+// StatusPageReconciler reconciles a StatusPage object
+type StatusPageReconciler struct {
+	client.Client
+	Scheme    *runtime.Scheme
+	Clients   *uptimerobot.ClientPool
+	Snapshots *urrecon.SnapshotCaches
+	Recorder  record.EventRecorder
+}
+
+func getStatusPage(ctx context.Context, reader client.Reader, req ctrl.Request) (uptimerobotcomv1alpha1.StatusPage, error) {
+	logger := log.FromContext(ctx)
+	statusPage := uptimerobotcomv1alpha1.StatusPage{}
+	err := reader.Get(ctx, req.NamespacedName, &statusPage)
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			logger.Error(err, "failed to retrieve status page resource")
+		}
+
+		logger.Info("requeue", "reason", "failed to get")
+		return uptimerobotcomv1alpha1.StatusPage{}, err
+	}
+
+	return statusPage, nil
+}
+
+func StatusPageSortToInt(sort uptimerobotcomv1alpha1.StatusPageSort) (int, error) {
+	switch sort {
+	case uptimerobotcomv1alpha1.SORT_A_Z, "":
+		return uptimerobot.PSPSortFriendlyNameAsc, nil
+	case uptimerobotcomv1alpha1.SORT_Z_A:
+		return uptimerobot.PSPSortFriendlyNameDesc, nil
+	case uptimerobotcomv1alpha1.SORT_UP_DOWN_PAUSED:
+		return uptimerobot.PSPSortStatusUpFirst, nil
+	case uptimerobotcomv1alpha1.SORT_DOWN_UP_PAUSED:
+		return uptimerobot.PSPSortStatusDownFirst, nil
+	default:
+		return 0, errors.New("unrecognised status page sort")
+	}
+}
+
+// getStatusPageSettings reads the settings of the page the api can't return, including the password
+func getStatusPageSettings(ctx context.Context, reader client.Reader, namespace string, spec uptimerobotcomv1alpha1.StatusPageSpec) (urrecon.StatusPageSettings, error) {
+	settings := urrecon.StatusPageSettings{
+		CustomDomain: spec.CustomDomain,
+		HideUrlLinks: spec.HideUrlLinks,
+	}
+
+	if spec.Password != nil {
+		password, err := getSecretKeyValue(ctx, reader, namespace, spec.Password.ValueFrom.SecretKeyRef)
+		if err != nil {
+			return urrecon.StatusPageSettings{}, err
+		}
+		settings.Password = password
+	}
+
+	return settings, nil
+}
+
+// getListOfMonitorIds returns the sorted ids of the synced Monitors of the Account accountRef names that the
+// selector matches in the namespace
+func getListOfMonitorIds(ctx context.Context, reader client.Reader, namespace string, accountRef *corev1.LocalObjectReference, labelSelector metav1.LabelSelector) ([]string, error) {
+	selector, err := metav1.LabelSelectorAsSelector(&labelSelector)
+	if err != nil {
+		return nil, invalidConfig(err)
+	}
+
+	monitors := uptimerobotcomv1alpha1.MonitorList{}
+	err = reader.List(ctx, &monitors, client.InNamespace(namespace), client.MatchingLabelsSelector{Selector: selector})
+	if err != nil {
+		log.FromContext(ctx).Info("failed to retrieve monitors for status page with selector", "selector", selector.String())
+		return nil, err
+	}
+
+	var ids []string
+	for _, monitor := range monitors.Items {
+		if monitor.Status.Id != "" && sameAccountRef(accountRef, monitor.Spec.AccountRef) {
+			ids = append(ids, monitor.Status.Id)
+		}
+	}
+	sort.Strings(ids)
+
+	return ids, nil
+}
+
+//+kubebuilder:rbac:groups=uptimerobot.com,resources=statuspages,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=uptimerobot.com,resources=statuspages/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=uptimerobot.com,resources=statuspages/finalizers,verbs=update
+//+kubebuilder:rbac:groups=uptimerobot.com,resources=monitors,verbs=get;list;watch
+//+kubebuilder:rbac:groups=uptimerobot.com,resources=accounts,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+
+func (reconciler *StatusPageReconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+	statusPage, err := getStatusPage(ctx, reconciler, request)
+	if err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	originalStatus := statusPage.Status.DeepCopy()
+
+	apiClient, err := getClientForAccountRef(ctx, reconciler, reconciler.Clients, statusPage.Namespace, statusPage.Spec.AccountRef)
+	if err != nil {
+		//a missing account can't come back to delete with, so a resource being deleted is let go
+		if apierrors.IsNotFound(err) && !statusPage.DeletionTimestamp.IsZero() {
+			return ctrl.Result{}, releaseFinalizer(ctx, reconciler.Client, reconciler.Recorder, &statusPage, FINALIZER_TOKEN, statusPage.Status.Id, err)
+		}
+
+		logger.Error(err, "failed to resolve uptimerobot account", "account", statusPage.Spec.AccountRef)
+		if apierrors.IsNotFound(err) {
+			err = invalidConfig(err)
+		}
+
+		return recordSyncFailure(ctx, reconciler.Recorder, reconciler.Client.Status(), &statusPage, &statusPage.Status.Conditions, err)
+	}
+
+	snapshot := reconciler.Snapshots.For(apiClient)
+	result, err := Finalize(ctx, reconciler.Client, &statusPage, FINALIZER_TOKEN, func(context.Context) error {
+		//nothing was created on the api, so there is nothing to delete
+		if statusPage.Status.Id == "" {
+			return nil
+		}
+
+		_, err := apiClient.DeletePSP(ctx, statusPage.Status.Id)
+		if err != nil {
+			if uptimerobot.IsNotFound(err) {
+				return nil
+			}
+			if uptimerobot.IsRateLimited(err) {
+				return err
+			}
+			logger.Error(err, "failed to delete status page", "id", statusPage.Status.Id, "reason", apiErrorReason(err))
+			return err
+		}
+
+		snapshot.RemoveStatusPage(statusPage.Status.Id)
+		recordDeleteEvent(reconciler.Recorder, &statusPage, statusPage.Status.Id)
+		return nil
+	})
+	if err != nil || result != controllerutil.OperationResultNone {
+		if rateLimitedResult, ok := requeueIfRateLimited(ctx, err); ok {
+			return rateLimitedResult, nil
+		}
+
+		if result != controllerutil.OperationResultNone {
+			logger.Error(err, "failed finalizing status page")
+		}
+
+		return ctrl.Result{}, err
+	}
+
+	statusWriter := reconciler.Client.Status()
+	monitorIds, err := getListOfMonitorIds(ctx, reconciler, statusPage.Namespace, statusPage.Spec.AccountRef, statusPage.Spec.Monitors)
+	if err != nil {
+		logger.Error(err, "failed to select monitors")
+		return recordSyncFailure(ctx, reconciler.Recorder, statusWriter, &statusPage, &statusPage.Status.Conditions, err)
+	}
+
+	//the api shows every monitor of the account on a page without monitors, so wait for one to be selected
+	if len(monitorIds) == 0 {
+		err = invalidConfig(errors.New("monitors selects no synced monitors"))
+		logger.Error(err, "failed to select monitors")
+		return recordSyncFailure(ctx, reconciler.Recorder, statusWriter, &statusPage, &statusPage.Status.Conditions, err)
+	}
+
+	settings, err := getStatusPageSettings(ctx, reconciler, statusPage.Namespace, statusPage.Spec)
+	if err != nil {
+		logger.Error(err, "failed to read status page password")
+		return recordSyncFailure(ctx, reconciler.Recorder, statusWriter, &statusPage, &statusPage.Status.Conditions, invalidConfig(err))
+	}
+
+	statusPageObj := urrecon.StatusPage{
+		Id:           statusPage.Status.Id,
+		SettingsHash: statusPage.Status.SettingsHash,
+	}
+
+	statusPageApiReconciler := urrecon.NewStatusPageApiReconciler(apiClient, snapshot)
+	result, err = urrecon.ReconcileApiObject[urrecon.StatusPage](ctx, &statusPageApiReconciler, &statusPageObj, func() error {
+		statusPageObj.Name = statusPage.Spec.Name
+		statusPageObj.Monitors = monitorIds
+		sortId, err := StatusPageSortToInt(statusPage.Spec.Sort)
+		if err != nil {
+			return invalidConfig(err)
+		}
+		statusPageObj.Sort = sortId
+		statusPageObj.Settings = settings
+		statusPageObj.SettingsHash = settings.Hash()
+		return nil
+	})
+	if err != nil {
+		if !uptimerobot.IsRateLimited(err) {
+			logger.Error(err, "failed updating status page on api", "reason", apiErrorReason(err))
+		}
+
+		return recordSyncFailure(ctx, reconciler.Recorder, statusWriter, &statusPage, &statusPage.Status.Conditions, err)
+	}
+
+	if result != controllerutil.OperationResultNone {
+		missingId := ""
+		if result == controllerutil.OperationResultCreated {
+			missingId = statusPage.Status.Id
+		}
+		event := syncEvent{
+			result:    result,
+			drifted:   statusPage.Status.ObservedGeneration == statusPage.Generation && statusPage.Status.SettingsHash == statusPageObj.SettingsHash,
+			adopted:   statusPage.Status.LastSyncTime == nil && missingId == "" && result != controllerutil.OperationResultCreated,
+			missingId: missingId,
+			id:        statusPageObj.Id,
+		}
+
+		statusPage.Status.Id = statusPageObj.Id
+		statusPage.Status.Name = statusPageObj.Name
+		statusPage.Status.Monitors = statusPageObj.Monitors
+		statusPage.Status.SettingsHash = statusPageObj.SettingsHash
+
+		//the urls of the page are only known to the api
+		statusPages, err := snapshot.StatusPages(ctx)
+		if err != nil {
+			if rateLimitedResult, ok := requeueIfRateLimited(ctx, err); ok {
+				return rateLimitedResult, nil
+			}
+			return ctrl.Result{}, err
+		}
+		apiStatusPage := statusPages[statusPageObj.Id]
+		statusPage.Status.Url = apiStatusPage.StandardUrl
+		statusPage.Status.CustomUrl = apiStatusPage.CustomUrl
+		statusPage.Status.Status = apiStatusPage.Status
+
+		statusPage.Status.ObservedGeneration = statusPage.Generation
+		statusPage.Status.LastSyncTime = syncTime(statusPage.Status.LastSyncTime, result)
+		setSyncSucceeded(&statusPage.Status.Conditions, statusPage.Generation, resultReason(result), "")
+		setRemoteMissing(&statusPage.Status.Conditions, statusPage.Generation, missingId, statusPageObj.Id)
+
+		recordSyncEvent(reconciler.Recorder, &statusPage, event)
+
+		err = updateStatusIfChanged(ctx, statusWriter, &statusPage, originalStatus, &statusPage.Status)
+		if err != nil {
+			logger.Error(err, "failed updating status")
+			return ctrl.Result{}, err
+		}
+	}
+
+	return ctrl.Result{
+		RequeueAfter: time.Second * 15,
+	}, nil
+}
+
+// statusPagesForSecret maps a Secret to the StatusPages in its namespace that read their password from it
+func (r *StatusPageReconciler) statusPagesForSecret(ctx context.Context, secret client.Object) []reconcile.Request {
+	logger := log.FromContext(ctx)
+	statusPages := uptimerobotcomv1alpha1.StatusPageList{}
+	err := r.List(ctx, &statusPages, client.InNamespace(secret.GetNamespace()))
+	if err != nil {
+		logger.Error(err, "failed to list status pages for secret", "secret", secret.GetName())
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, statusPage := range statusPages.Items {
+		password := statusPage.Spec.Password
+		if password != nil && password.ValueFrom.SecretKeyRef.Name == secret.GetName() {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&statusPage)})
+		}
+	}
+
+	return requests
+}
+
+// statusPagesForMonitor maps a Monitor to the StatusPages in its namespace that select it, so a monitor
+// is shown once it has an id
+func (r *StatusPageReconciler) statusPagesForMonitor(ctx context.Context, monitor client.Object) []reconcile.Request {
+	logger := log.FromContext(ctx)
+	statusPages := uptimerobotcomv1alpha1.StatusPageList{}
+	err := r.List(ctx, &statusPages, client.InNamespace(monitor.GetNamespace()))
+	if err != nil {
+		logger.Error(err, "failed to list status pages for monitor", "monitor", monitor.GetName())
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, statusPage := range statusPages.Items {
+		selector, err := metav1.LabelSelectorAsSelector(&statusPage.Spec.Monitors)
+		if err != nil {
+			continue
+		}
+
+		if selector.Matches(labels.Set(monitor.GetLabels())) {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&statusPage)})
+		}
+	}
+
+	return requests
+}
+
+// monitorId is the id a Monitor is shown on its status pages with
+func monitorId(object client.Object) interface{} {
+	return object.(*uptimerobotcomv1alpha1.Monitor).Status.Id
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *StatusPageReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&uptimerobotcomv1alpha1.StatusPage{}, builder.WithPredicates(specChanged)).
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(r.statusPagesForSecret)).
+		Watches(&uptimerobotcomv1alpha1.Monitor{}, handler.EnqueueRequestsFromMapFunc(r.statusPagesForMonitor),
+			builder.WithPredicates(resourceChanged(monitorId))).
+		Complete(r)
+}
@@ -2,10 +2,10 @@ package urrecon
 
 import (
 	"context"
-	"errors"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"strconv"
-	"strings"
 
 	"github.com/luckielordie/uptime-robot-operator/internal/uptimerobot"
 	"sigs.k8s.io/controller-runtime/pkg/log"
@@ -14,7 +14,6 @@ import (
 type AlertContactApiClient interface {
 	uptimerobot.AlertContactCreator
 	uptimerobot.AlertContactEditor
-	uptimerobot.AlertContactGetter
 }
 
 type AlertContact struct {
@@ -25,13 +24,37 @@ type AlertContact struct {
 	Value  string
 }
 
+// ValueHash identifies the value without revealing it
+func (alertContact AlertContact) ValueHash() string {
+	if alertContact.Value == "" {
+		return ""
+	}
+
+	sum := sha256.Sum256([]byte(alertContact.Value))
+	return hex.EncodeToString(sum[:])
+}
+
+// loggedAlertContact stops MarshalLog recursing into itself
+type loggedAlertContact AlertContact
+
+// MarshalLog keeps the value, which may have been read from a Secret, out of the logs
+func (alertContact AlertContact) MarshalLog() interface{} {
+	if alertContact.Value != "" {
+		alertContact.Value = "[redacted]"
+	}
+
+	return loggedAlertContact(alertContact)
+}
+
 type AlertContactApiReconciler struct {
 	apiClient AlertContactApiClient
+	snapshot  *SnapshotCache
 }
 
-func NewAlertContactApiReconciler(apiClient AlertContactApiClient) AlertContactApiReconciler {
+func NewAlertContactApiReconciler(apiClient AlertContactApiClient, snapshot *SnapshotCache) AlertContactApiReconciler {
 	return AlertContactApiReconciler{
 		apiClient: apiClient,
+		snapshot:  snapshot,
 	}
 }
 
@@ -46,6 +69,7 @@ func (reconciler *AlertContactApiReconciler) CreateApiObject(ctx context.Context
 	logger.Info("successful api request", "response", response)
 	alertContact.Id = strconv.Itoa(response.AlertContact.Id)
 	alertContact.Status = 0
+	reconciler.snapshot.InvalidateAlertContacts()
 
 	return nil
 }
@@ -59,6 +83,10 @@ func (reconciler *AlertContactApiReconciler) EditApiObject(ctx context.Context,
 		return err
 	}
 	logger.Info("successful api request", "response", response)
+	reconciler.snapshot.UpdateAlertContact(alertContact.Id, func(apiAlertContact *uptimerobot.AlertContactDetails) {
+		apiAlertContact.FriendlyName = alertContact.Name
+		apiAlertContact.Value = alertContact.Value
+	})
 
 	return nil
 }
@@ -69,39 +97,32 @@ func (reconciler *AlertContactApiReconciler) ApiObjectExists(ctx context.Context
 		return false, nil
 	}
 
-	//check if object exists through the API
-	_, err := reconciler.apiClient.GetAlertContacts(ctx, []string{alertContact.Id})
+	//check if object exists in the account snapshot
+	alertContacts, err := reconciler.snapshot.AlertContacts(ctx)
 	if err != nil {
-		errStr := err.Error()
-		if strings.Contains(errStr, "not_found") {
-			return false, nil
-		}
-
 		return false, err
 	}
 
-	return true, nil
+	_, exists := alertContacts[alertContact.Id]
+	return exists, nil
 }
 
 func (reconciler *AlertContactApiReconciler) GetApiObject(ctx context.Context, alertContact *AlertContact) (*AlertContact, error) {
-	apiResponse, err := reconciler.apiClient.GetAlertContacts(ctx, []string{alertContact.Id})
+	alertContacts, err := reconciler.snapshot.AlertContacts(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("unexpected error with alert-contact api: %w", err)
 	}
 
-	if apiResponse.AlertContacts == nil {
-		return nil, errors.New("api returned no alert-contacts when one was expected")
-	}
-
-	if len(apiResponse.AlertContacts) != 1 {
-		return nil, errors.New("api returned more than one alert-contact when only one was expected")
+	apiAlertContact, ok := alertContacts[alertContact.Id]
+	if !ok {
+		return nil, fmt.Errorf("api returned no alert-contact with id %s when one was expected", alertContact.Id)
 	}
 
 	return &AlertContact{
-		Id:     apiResponse.AlertContacts[0].Id,
-		Name:   apiResponse.AlertContacts[0].FriendlyName,
-		Type:   apiResponse.AlertContacts[0].Type,
-		Status: apiResponse.AlertContacts[0].Status,
-		Value:  apiResponse.AlertContacts[0].Value,
+		Id:     apiAlertContact.Id,
+		Name:   apiAlertContact.FriendlyName,
+		Type:   apiAlertContact.Type,
+		Status: apiAlertContact.Status,
+		Value:  apiAlertContact.Value,
 	}, nil
 }
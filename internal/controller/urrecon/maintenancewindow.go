@@ -0,0 +1,120 @@
+package urrecon
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/luckielordie/uptime-robot-operator/internal/uptimerobot"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+type MaintenanceWindowApiClient interface {
+	uptimerobot.MWindowCreator
+	uptimerobot.MWindowEditor
+}
+
+type MaintenanceWindow struct {
+	Id    string
+	Name  string
+	Type  int
+	Value string
+	Start string
+	// Duration is in minutes
+	Duration int
+}
+
+type MaintenanceWindowApiReconciler struct {
+	apiClient MaintenanceWindowApiClient
+	snapshot  *SnapshotCache
+}
+
+func NewMaintenanceWindowApiReconciler(apiClient MaintenanceWindowApiClient, snapshot *SnapshotCache) MaintenanceWindowApiReconciler {
+	return MaintenanceWindowApiReconciler{
+		apiClient: apiClient,
+		snapshot:  snapshot,
+	}
+}
+
+func (reconciler *MaintenanceWindowApiReconciler) CreateApiObject(ctx context.Context, mwindow *MaintenanceWindow) error {
+	logger := log.FromContext(ctx)
+	response, err := reconciler.apiClient.NewMWindow(ctx, uptimerobot.NewMWindowRequest{
+		FriendlyName: mwindow.Name,
+		Type:         mwindow.Type,
+		Value:        mwindow.Value,
+		StartTime:    mwindow.Start,
+		Duration:     mwindow.Duration,
+	})
+	if err != nil {
+		logger.Info("failed api request", "response", response)
+		return err
+	}
+
+	logger.Info("successful api request", "response", response)
+	mwindow.Id = strconv.Itoa(response.MWindow.Id)
+	reconciler.snapshot.InvalidateMaintenanceWindows()
+
+	return nil
+}
+
+func (reconciler *MaintenanceWindowApiReconciler) EditApiObject(ctx context.Context, mwindow *MaintenanceWindow) error {
+	logger := log.FromContext(ctx)
+
+	response, err := reconciler.apiClient.EditMWindow(ctx, uptimerobot.EditMWindowRequest{
+		Id:           mwindow.Id,
+		FriendlyName: mwindow.Name,
+		Value:        mwindow.Value,
+		StartTime:    mwindow.Start,
+		Duration:     mwindow.Duration,
+	})
+	if err != nil {
+		logger.Info("failed api request", "response", response)
+		return err
+	}
+	logger.Info("successful api request", "response", response)
+	reconciler.snapshot.UpdateMaintenanceWindow(mwindow.Id, func(apiMWindow *uptimerobot.MWindowDetails) {
+		apiMWindow.FriendlyName = mwindow.Name
+		apiMWindow.Value = mwindow.Value
+		apiMWindow.StartTime = uptimerobot.MWindowStartTime(mwindow.Start)
+		apiMWindow.Duration = mwindow.Duration
+	})
+
+	return nil
+}
+
+func (reconciler *MaintenanceWindowApiReconciler) ApiObjectExists(ctx context.Context, mwindow *MaintenanceWindow) (bool, error) {
+	// if id is an empty string then the object can't exist
+	if mwindow.Id == "" {
+		return false, nil
+	}
+
+	//check if object exists in the account snapshot
+	mwindows, err := reconciler.snapshot.MaintenanceWindows(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	_, exists := mwindows[mwindow.Id]
+	return exists, nil
+}
+
+func (reconciler *MaintenanceWindowApiReconciler) GetApiObject(ctx context.Context, mwindow *MaintenanceWindow) (*MaintenanceWindow, error) {
+	mwindows, err := reconciler.snapshot.MaintenanceWindows(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unexpected error with maintenance window api: %w", err)
+	}
+
+	apiMWindow, ok := mwindows[mwindow.Id]
+	if !ok {
+		return nil, fmt.Errorf("api returned no maintenance window with id %s when one was expected", mwindow.Id)
+	}
+
+	return &MaintenanceWindow{
+		Id:       strconv.Itoa(int(apiMWindow.Id)),
+		Name:     apiMWindow.FriendlyName,
+		Type:     apiMWindow.Type,
+		Value:    apiMWindow.Value,
+		Start:    string(apiMWindow.StartTime),
+		Duration: apiMWindow.Duration,
+	}, nil
+}
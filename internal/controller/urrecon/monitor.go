@@ -2,8 +2,11 @@ package urrecon
 
 import (
 	"context"
-	"errors"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -14,32 +17,134 @@ import (
 type MonitorApiClient interface {
 	uptimerobot.MonitorCreator
 	uptimerobot.MonitorEditor
-	uptimerobot.MonitorGetter
+}
+
+const (
+	pingMonitorType      = 3
+	heartbeatMonitorType = 5
+	customPortSubType    = 99
+
+	pauseMonitorStatus  = 0
+	resumeMonitorStatus = 1
+)
+
+// MonitorHttp are the settings of the request http and keyword monitors make and of the checks of
+// their url. The api doesn't return all of them, or returns them redacted, so changes are detected
+// through their Hash.
+type MonitorHttp struct {
+	Method          string
+	PostType        int
+	PostValue       string
+	PostContentType int
+	AuthType        int
+	Username        string
+	Password        string
+	Headers         string
+	Statuses        string
+	// the certificate and domain expiry checks of the url can't be read back either
+	IgnoreSslErrors                  bool
+	SslExpirationReminder            bool
+	DisableDomainExpireNotifications bool
+}
+
+// Hash identifies the settings without revealing the credentials in them, it is empty when nothing is set
+func (http MonitorHttp) Hash() string {
+	if http == (MonitorHttp{}) {
+		return ""
+	}
+
+	encoded, _ := json.Marshal(http)
+	sum := sha256.Sum256(encoded)
+	return hex.EncodeToString(sum[:])
 }
 
 type Monitor struct {
-	Id            string
-	Name          string
-	Url           string
+	Id              string
+	Name            string
+	Url             string
+	Type            int
+	SubType         int
+	Port            int
+	KeywordType     int
+	KeywordCaseType int
+	KeywordValue    string
+	Interval        int
+	Timeout         int
+	Http            MonitorHttp
+	// HttpHash is the Hash of the http settings last sent to the api
+	HttpHash string
+	// AlertContacts are the sorted id_threshold_recurrence triples of the contacts the monitor notifies
 	AlertContacts []string
+	// MaintenanceWindows are the sorted ids of the windows attached to the monitor
+	MaintenanceWindows []string
+	Paused             bool
+}
+
+// editStatus is the status editMonitor pauses or resumes the monitor with
+func (monitor Monitor) editStatus() *int {
+	status := resumeMonitorStatus
+	if monitor.Paused {
+		status = pauseMonitorStatus
+	}
+
+	return &status
+}
+
+// loggedMonitor stops MarshalLog recursing into itself
+type loggedMonitor Monitor
+
+// MarshalLog keeps credentials out of the logs, the http settings and keyword may have been read
+// from Secrets so only the hash of the http settings is logged
+func (monitor Monitor) MarshalLog() interface{} {
+	monitor.Http = MonitorHttp{}
+	if monitor.KeywordValue != "" {
+		monitor.KeywordValue = "[redacted]"
+	}
+
+	return loggedMonitor(monitor)
 }
 
 type MonitorApiReconciler struct {
 	apiClient MonitorApiClient
+	snapshot  *SnapshotCache
 }
 
-func NewMonitorApiReconciler(apiClient MonitorApiClient) MonitorApiReconciler {
+func NewMonitorApiReconciler(apiClient MonitorApiClient, snapshot *SnapshotCache) MonitorApiReconciler {
 	return MonitorApiReconciler{
 		apiClient: apiClient,
+		snapshot:  snapshot,
 	}
 }
 
+// CreateApiObject creates the monitor active, newMonitor can't create it paused. A paused
+// monitor is paused by the edit of the next reconcile, which sees it running.
 func (reconciler *MonitorApiReconciler) CreateApiObject(ctx context.Context, monitor *Monitor) error {
 	logger := log.FromContext(ctx)
 	response, err := reconciler.apiClient.NewMonitor(ctx, uptimerobot.NewMonitorRequest{
-		FriendlyName:  monitor.Name,
-		Url:           monitor.Url,
-		AlertContacts: monitor.AlertContacts,
+		FriendlyName:                     monitor.Name,
+		Url:                              monitor.Url,
+		MonitorType:                      monitor.Type,
+		SubType:                          monitor.SubType,
+		Port:                             monitor.Port,
+		KeywordType:                      monitor.KeywordType,
+		KeywordCaseType:                  monitor.KeywordCaseType,
+		KeywordValue:                     monitor.KeywordValue,
+		Interval:                         monitor.Interval,
+		Timeout:                          monitor.Timeout,
+		HttpMethod:                       monitor.Http.Method,
+		PostType:                         monitor.Http.PostType,
+		PostValue:                        monitor.Http.PostValue,
+		PostContentType:                  monitor.Http.PostContentType,
+		HttpAuthType:                     monitor.Http.AuthType,
+		HttpUsername:                     monitor.Http.Username,
+		HttpPassword:                     monitor.Http.Password,
+		CustomHttpHeaders:                monitor.Http.Headers,
+		CustomHttpStatuses:               monitor.Http.Statuses,
+		AlertContacts:                    monitor.AlertContacts,
+		MaintenanceWindows:               strings.Join(monitor.MaintenanceWindows, "-"),
+		IgnoreSSLErrors:                  monitor.Http.IgnoreSslErrors,
+		SSLExpirationReminder:            monitor.Http.SslExpirationReminder,
+		DisableDomainExpireNotifications: monitor.Http.DisableDomainExpireNotifications,
 	})
 	if err != nil {
 		logger.Info("failed api request", "response", response)
@@ -48,6 +153,7 @@ func (reconciler *MonitorApiReconciler) CreateApiObject(ctx context.Context, mon
 
 	logger.Info("successful api request", "response", response)
 	monitor.Id = strconv.Itoa(response.Monitor.Id)
+	reconciler.snapshot.InvalidateMonitors()
 
 	return nil
 }
@@ -56,10 +162,32 @@ func (reconciler *MonitorApiReconciler) EditApiObject(ctx context.Context, monit
 	logger := log.FromContext(ctx)
 
 	response, err := reconciler.apiClient.EditMonitor(ctx, uptimerobot.EditMonitorRequest{
-		Id:            monitor.Id,
-		FriendlyName:  monitor.Name,
-		Url:           monitor.Url,
-		AlertContacts: monitor.AlertContacts,
+		Id:                               monitor.Id,
+		MonitorType:                      monitor.Type,
+		FriendlyName:                     monitor.Name,
+		Url:                              monitor.Url,
+		SubType:                          monitor.SubType,
+		Port:                             monitor.Port,
+		KeywordType:                      monitor.KeywordType,
+		KeywordCaseType:                  monitor.KeywordCaseType,
+		KeywordValue:                     monitor.KeywordValue,
+		Interval:                         monitor.Interval,
+		Timeout:                          monitor.Timeout,
+		Status:                           monitor.editStatus(),
+		HttpMethod:                       monitor.Http.Method,
+		PostType:                         monitor.Http.PostType,
+		PostValue:                        monitor.Http.PostValue,
+		PostContentType:                  monitor.Http.PostContentType,
+		HttpAuthType:                     monitor.Http.AuthType,
+		HttpUsername:                     monitor.Http.Username,
+		HttpPassword:                     monitor.Http.Password,
+		CustomHttpHeaders:                monitor.Http.Headers,
+		CustomHttpStatuses:               monitor.Http.Statuses,
+		AlertContacts:                    monitor.AlertContacts,
+		MaintenanceWindows:               strings.Join(monitor.MaintenanceWindows, "-"),
+		IgnoreSSLErrors:                  monitor.Http.IgnoreSslErrors,
+		SSLExpirationReminder:            monitor.Http.SslExpirationReminder,
+		DisableDomainExpireNotifications: monitor.Http.DisableDomainExpireNotifications,
 	})
 	if err != nil {
 		logger.Info("failed api request", "response", response)
@@ -67,49 +195,129 @@ func (reconciler *MonitorApiReconciler) EditApiObject(ctx context.Context, monit
 	}
 	logger.Info("successful api request", "response", response)
 
+	alertContacts, mwindows, err := monitor.attachments()
+	if err != nil {
+		reconciler.snapshot.InvalidateMonitors()
+		return nil
+	}
+	reconciler.snapshot.UpdateMonitor(monitor.Id, func(apiMonitor *uptimerobot.MonitorDetails) {
+		apiMonitor.FriendlyName = monitor.Name
+		//the api generates the url of heartbeat monitors
+		if apiMonitor.MonitorType != heartbeatMonitorType {
+			apiMonitor.Url = monitor.Url
+		}
+		apiMonitor.SubType = uptimerobot.OptionalInt(monitor.SubType)
+		apiMonitor.Port = uptimerobot.OptionalInt(monitor.Port)
+		apiMonitor.KeywordType = uptimerobot.OptionalInt(monitor.KeywordType)
+		apiMonitor.KeywordCaseType = uptimerobot.OptionalInt(monitor.KeywordCaseType)
+		apiMonitor.KeywordValue = monitor.KeywordValue
+		apiMonitor.Interval = monitor.Interval
+		apiMonitor.Timeout = uptimerobot.OptionalInt(monitor.Timeout)
+		apiMonitor.AlertContacts = alertContacts
+		apiMonitor.MaintenanceWindows = mwindows
+		//a resumed monitor isn't checked until its next interval
+		if monitor.Paused {
+			apiMonitor.Status = uptimerobot.MonitorStatusPaused
+		} else if apiMonitor.Status == uptimerobot.MonitorStatusPaused {
+			apiMonitor.Status = uptimerobot.MonitorStatusNotChecked
+		}
+	})
+
 	return nil
 }
 
+// attachments decodes the alert contacts and maintenance windows of the monitor the way the api returns them
+func (monitor Monitor) attachments() ([]uptimerobot.MonitorAlertContact, []uptimerobot.MonitorMWindow, error) {
+	var alertContacts []uptimerobot.MonitorAlertContact
+	for _, triple := range monitor.AlertContacts {
+		alertContact := uptimerobot.MonitorAlertContact{}
+		_, err := fmt.Sscanf(triple, "%d_%d_%d", &alertContact.Id, &alertContact.Threshold, &alertContact.Recurrence)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid alert contact %q: %w", triple, err)
+		}
+		alertContacts = append(alertContacts, alertContact)
+	}
+
+	var mwindows []uptimerobot.MonitorMWindow
+	for _, id := range monitor.MaintenanceWindows {
+		idInt, err := strconv.Atoi(id)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid maintenance window id %q: %w", id, err)
+		}
+		mwindows = append(mwindows, uptimerobot.MonitorMWindow{Id: uptimerobot.OptionalInt(idInt)})
+	}
+
+	return alertContacts, mwindows, nil
+}
+
 func (reconciler *MonitorApiReconciler) ApiObjectExists(ctx context.Context, monitor *Monitor) (bool, error) {
 	// if id is an empty string then the object can't exist
 	if monitor.Id == "" {
 		return false, nil
 	}
 
-	//check if object exists through the API
-	_, err := reconciler.apiClient.GetMonitors(ctx, []string{monitor.Id})
+	//check if object exists in the account snapshot
+	monitors, err := reconciler.snapshot.Monitors(ctx)
 	if err != nil {
-		errStr := err.Error()
-		if strings.Contains(errStr, "not_found") {
-			return false, nil
-		}
-
 		return false, err
 	}
 
-	return true, nil
+	_, exists := monitors[monitor.Id]
+	return exists, nil
 }
 
 func (reconciler *MonitorApiReconciler) GetApiObject(ctx context.Context, monitor *Monitor) (*Monitor, error) {
-	apiResponse, err := reconciler.apiClient.GetMonitors(ctx, []string{monitor.Id})
+	monitors, err := reconciler.snapshot.Monitors(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("unexpected error with monitor api: %w", err)
 	}
 
-	if apiResponse.Monitors == nil {
-		return nil, errors.New("api returned no monitors when one was expected")
+	apiMonitor, ok := monitors[monitor.Id]
+	if !ok {
+		return nil, fmt.Errorf("api returned no monitor with id %s when one was expected", monitor.Id)
+	}
+
+	remote := &Monitor{
+		Id:              apiMonitor.Id,
+		Name:            apiMonitor.FriendlyName,
+		Url:             apiMonitor.Url,
+		Type:            apiMonitor.MonitorType,
+		SubType:         int(apiMonitor.SubType),
+		Port:            int(apiMonitor.Port),
+		KeywordType:     int(apiMonitor.KeywordType),
+		KeywordCaseType: int(apiMonitor.KeywordCaseType),
+		KeywordValue:    apiMonitor.KeywordValue,
+		Interval:        apiMonitor.Interval,
+		Timeout:         int(apiMonitor.Timeout),
+		Http:            monitor.Http,     //http settings can't be read back from the api...
+		HttpHash:        monitor.HttpHash, //...so compare the hash of what was last sent instead
+		Paused:          apiMonitor.Status == uptimerobot.MonitorStatusPaused,
+	}
+
+	for _, alertContact := range apiMonitor.AlertContacts {
+		remote.AlertContacts = append(remote.AlertContacts, alertContact.String())
 	}
+	sort.Strings(remote.AlertContacts)
 
-	if len(apiResponse.Monitors) != 1 {
-		return nil, errors.New("api returned more than one monitor when only one was expected")
+	for _, mwindow := range apiMonitor.MaintenanceWindows {
+		remote.MaintenanceWindows = append(remote.MaintenanceWindows, strconv.Itoa(int(mwindow.Id)))
 	}
+	sort.Strings(remote.MaintenanceWindows)
 
-	apiMonitor := apiResponse.Monitors[0]
+	//ping and heartbeat monitors don't wait for a response so their timeout is meaningless
+	if remote.Type == pingMonitorType || remote.Type == heartbeatMonitorType {
+		remote.Timeout = 0
+	}
+
+	//the api generates the url of heartbeat monitors
+	if remote.Type == heartbeatMonitorType {
+		remote.Url = monitor.Url
+	}
+
+	//the api reports the well known port of the sub type, only custom ports are set by us
+	if remote.SubType != customPortSubType {
+		remote.Port = monitor.Port
+	}
 
-	return &Monitor{
-		Id:            apiMonitor.Id,
-		Name:          apiMonitor.FriendlyName,
-		Url:           apiMonitor.Url,
-		AlertContacts: monitor.AlertContacts, //alert contacts aren't available on the API
-	}, nil
+	return remote, nil
 }
@@ -0,0 +1,433 @@
+package urrecon
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/luckielordie/uptime-robot-operator/internal/uptimerobot"
+	"golang.org/x/sync/singleflight"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const DefaultSnapshotMaxAge = time.Minute
+
+// MonitorLogsLimit is how many of each monitor's latest logs the snapshot keeps
+const MonitorLogsLimit = 10
+
+type SnapshotApiClient interface {
+	uptimerobot.AccountDetailsGetter
+	uptimerobot.MonitorLister
+	uptimerobot.AlertContactLister
+	uptimerobot.MWindowLister
+	uptimerobot.PSPLister
+}
+
+// snapshotEntry holds one kind of api object, fetched in full and refreshed once it is older than maxAge.
+// version counts the changes made to it, so a fetch that raced one isn't kept.
+type snapshotEntry[Details any] struct {
+	objects   map[string]Details
+	fetchedAt time.Time
+	version   uint64
+}
+
+func (entry *snapshotEntry[Details]) isFresh(maxAge time.Duration) bool {
+	return entry.objects != nil && time.Since(entry.fetchedAt) < maxAge
+}
+
+// update applies change to a copy of the object with the id, when the entry holds it. Readers may still
+// hold the current map, so the copy goes into a new one.
+func (entry *snapshotEntry[Details]) update(id string, change func(object *Details)) {
+	object, ok := entry.objects[id]
+	if !ok {
+		return
+	}
+
+	change(&object)
+	objects := make(map[string]Details, len(entry.objects))
+	for key, value := range entry.objects {
+		objects[key] = value
+	}
+	objects[id] = object
+	entry.objects = objects
+	entry.version++
+}
+
+// updateAll applies change to a copy of every object, copying the map like update
+func (entry *snapshotEntry[Details]) updateAll(change func(object *Details)) {
+	if entry.objects == nil {
+		return
+	}
+
+	objects := make(map[string]Details, len(entry.objects))
+	for key, object := range entry.objects {
+		change(&object)
+		objects[key] = object
+	}
+	entry.objects = objects
+	entry.version++
+}
+
+// remove drops the object with the id, copying the map like update
+func (entry *snapshotEntry[Details]) remove(id string) {
+	if _, ok := entry.objects[id]; !ok {
+		return
+	}
+
+	objects := make(map[string]Details, len(entry.objects))
+	for key, value := range entry.objects {
+		if key != id {
+			objects[key] = value
+		}
+	}
+	entry.objects = objects
+	entry.version++
+}
+
+// invalidate drops the objects so the next read fetches them again
+func (entry *snapshotEntry[Details]) invalidate() {
+	entry.objects = nil
+	entry.version++
+}
+
+// fetchEntry returns the entry's objects, fetching them when they aren't fresh. The fetch runs outside
+// the cache's lock and is shared by everyone reading the kind meanwhile, its result is only kept when
+// nothing changed the entry while it ran.
+func fetchEntry[Details any](ctx context.Context, cache *SnapshotCache, kind string, entry *snapshotEntry[Details], fetch func(ctx context.Context) (map[string]Details, error)) (map[string]Details, error) {
+	cache.mutex.Lock()
+	if entry.isFresh(cache.maxAge) {
+		objects := entry.objects
+		cache.mutex.Unlock()
+		return objects, nil
+	}
+	cache.mutex.Unlock()
+
+	objects, err, _ := cache.fetches.Do(kind, func() (interface{}, error) {
+		cache.mutex.Lock()
+		version := entry.version
+		cache.mutex.Unlock()
+
+		fetchedAt := time.Now()
+		objects, err := fetch(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		cache.mutex.Lock()
+		defer cache.mutex.Unlock()
+		if entry.version == version {
+			entry.objects = objects
+			entry.fetchedAt = fetchedAt
+		}
+		return objects, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return objects.(map[string]Details), nil
+}
+
+// SnapshotCache keeps the account's details and a copy of every monitor, alert contact, maintenance window and status page in an account so that
+// reconciling N objects costs a handful of paginated list calls instead of N get calls.
+// Edits and deletes through the api must update the cached copy so the next read sees them, creates invalidate
+// it so the next read fetches what the api generated for the new object.
+type SnapshotCache struct {
+	mutex          sync.Mutex
+	fetches        singleflight.Group
+	apiClient      SnapshotApiClient
+	maxAge         time.Duration
+	monitorOptions uptimerobot.ListMonitorsOptions
+	accountDetails snapshotEntry[uptimerobot.GetAccountDetailsResponse]
+	monitors       snapshotEntry[uptimerobot.MonitorDetails]
+	alertContacts  snapshotEntry[uptimerobot.AlertContactDetails]
+	mwindows       snapshotEntry[uptimerobot.MWindowDetails]
+	psps           snapshotEntry[uptimerobot.PSPDetails]
+}
+
+func NewSnapshotCache(apiClient SnapshotApiClient, maxAge time.Duration) *SnapshotCache {
+	return &SnapshotCache{
+		apiClient: apiClient,
+		maxAge:    maxAge,
+		//logs report when monitors last went up or down, the latest few are enough for that
+		monitorOptions: uptimerobot.ListMonitorsOptions{
+			IncludeLogs:               true,
+			LogsLimit:                 MonitorLogsLimit,
+			IncludeAllTimeUptimeRatio: true,
+			//the average response time is reported regardless of how many response times are returned
+			IncludeResponseTimes: true,
+			ResponseTimesLimit:   1,
+			IncludeSSL:           true,
+			//contacts and windows are attached by the operator, so they are compared with what the api reports
+			IncludeAlertContacts:      true,
+			IncludeMaintenanceWindows: true,
+		},
+	}
+}
+
+// AccountDetails returns the account's details, such as the plan's minimum monitor interval
+func (cache *SnapshotCache) AccountDetails(ctx context.Context) (uptimerobot.GetAccountDetailsResponse, error) {
+	objects, err := fetchEntry(ctx, cache, "account", &cache.accountDetails, func(ctx context.Context) (map[string]uptimerobot.GetAccountDetailsResponse, error) {
+		accountDetails, err := cache.apiClient.GetAccountDetails(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		//the account is the only object of its kind, so it is kept under an empty id
+		return map[string]uptimerobot.GetAccountDetailsResponse{"": accountDetails}, nil
+	})
+	if err != nil {
+		return uptimerobot.GetAccountDetailsResponse{}, err
+	}
+
+	return objects[""], nil
+}
+
+// Monitors returns every monitor in the account keyed by id
+func (cache *SnapshotCache) Monitors(ctx context.Context) (map[string]uptimerobot.MonitorDetails, error) {
+	return fetchEntry(ctx, cache, "monitors", &cache.monitors, func(ctx context.Context) (map[string]uptimerobot.MonitorDetails, error) {
+		cache.mutex.Lock()
+		options := cache.monitorOptions
+		options.CustomUptimeRatios = append([]int{}, cache.monitorOptions.CustomUptimeRatios...)
+		cache.mutex.Unlock()
+
+		monitors := map[string]uptimerobot.MonitorDetails{}
+		err := cache.apiClient.ListAllMonitors(ctx, options, func(monitor uptimerobot.MonitorDetails) error {
+			monitors[monitor.Id] = monitor
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		log.FromContext(ctx).V(1).Info("refreshed monitor snapshot", "monitors", len(monitors))
+		return monitors, nil
+	})
+}
+
+// AlertContacts returns every alert contact in the account keyed by id
+func (cache *SnapshotCache) AlertContacts(ctx context.Context) (map[string]uptimerobot.AlertContactDetails, error) {
+	return fetchEntry(ctx, cache, "alertContacts", &cache.alertContacts, func(ctx context.Context) (map[string]uptimerobot.AlertContactDetails, error) {
+		alertContacts := map[string]uptimerobot.AlertContactDetails{}
+		err := cache.apiClient.ListAllAlertContacts(ctx, uptimerobot.ListAlertContactsOptions{}, func(alertContact uptimerobot.AlertContactDetails) error {
+			alertContacts[alertContact.Id] = alertContact
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		log.FromContext(ctx).V(1).Info("refreshed alert contact snapshot", "alertContacts", len(alertContacts))
+		return alertContacts, nil
+	})
+}
+
+// MaintenanceWindows returns every maintenance window in the account keyed by id
+func (cache *SnapshotCache) MaintenanceWindows(ctx context.Context) (map[string]uptimerobot.MWindowDetails, error) {
+	return fetchEntry(ctx, cache, "maintenanceWindows", &cache.mwindows, func(ctx context.Context) (map[string]uptimerobot.MWindowDetails, error) {
+		mwindows := map[string]uptimerobot.MWindowDetails{}
+		err := cache.apiClient.ListAllMWindows(ctx, uptimerobot.ListMWindowsOptions{}, func(mwindow uptimerobot.MWindowDetails) error {
+			mwindows[strconv.Itoa(int(mwindow.Id))] = mwindow
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		log.FromContext(ctx).V(1).Info("refreshed maintenance window snapshot", "maintenanceWindows", len(mwindows))
+		return mwindows, nil
+	})
+}
+
+// StatusPages returns every status page in the account keyed by id
+func (cache *SnapshotCache) StatusPages(ctx context.Context) (map[string]uptimerobot.PSPDetails, error) {
+	return fetchEntry(ctx, cache, "statusPages", &cache.psps, func(ctx context.Context) (map[string]uptimerobot.PSPDetails, error) {
+		psps := map[string]uptimerobot.PSPDetails{}
+		err := cache.apiClient.ListAllPSPs(ctx, uptimerobot.ListPSPsOptions{}, func(psp uptimerobot.PSPDetails) error {
+			psps[strconv.Itoa(int(psp.Id))] = psp
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		log.FromContext(ctx).V(1).Info("refreshed status page snapshot", "statusPages", len(psps))
+		return psps, nil
+	})
+}
+
+// RequestUptimeRatios adds periods, in days, to the uptime ratios fetched with the monitors. The snapshot
+// is shared by every monitor in the account so it fetches the union of the periods they request,
+// and refetches the monitors when a period is new.
+func (cache *SnapshotCache) RequestUptimeRatios(periods []int) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	requested := map[int]bool{}
+	for _, period := range cache.monitorOptions.CustomUptimeRatios {
+		requested[period] = true
+	}
+
+	added := false
+	for _, period := range periods {
+		if !requested[period] {
+			requested[period] = true
+			cache.monitorOptions.CustomUptimeRatios = append(cache.monitorOptions.CustomUptimeRatios, period)
+			added = true
+		}
+	}
+
+	if added {
+		sort.Ints(cache.monitorOptions.CustomUptimeRatios)
+		cache.monitors.invalidate()
+	}
+}
+
+// InvalidateMonitors forces the next read to fetch monitors from the api again
+func (cache *SnapshotCache) InvalidateMonitors() {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	cache.monitors.invalidate()
+}
+
+// InvalidateAlertContacts forces the next read to fetch alert contacts from the api again
+func (cache *SnapshotCache) InvalidateAlertContacts() {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	cache.alertContacts.invalidate()
+}
+
+// InvalidateMaintenanceWindows forces the next read to fetch maintenance windows from the api again
+func (cache *SnapshotCache) InvalidateMaintenanceWindows() {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	cache.mwindows.invalidate()
+}
+
+// InvalidateStatusPages forces the next read to fetch status pages from the api again
+func (cache *SnapshotCache) InvalidateStatusPages() {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	cache.psps.invalidate()
+}
+
+// UpdateMonitor applies an edit sent to the api to the cached monitor with the id
+func (cache *SnapshotCache) UpdateMonitor(id string, change func(monitor *uptimerobot.MonitorDetails)) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	cache.monitors.update(id, change)
+}
+
+// RemoveMonitor drops the monitor with the id once the api deleted it, along with its place on the
+// status pages the api removed it from
+func (cache *SnapshotCache) RemoveMonitor(id string) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	cache.monitors.remove(id)
+	cache.psps.updateAll(func(psp *uptimerobot.PSPDetails) {
+		var monitors uptimerobot.PSPMonitors
+		for _, monitorId := range psp.Monitors {
+			if strconv.Itoa(monitorId) != id {
+				monitors = append(monitors, monitorId)
+			}
+		}
+		psp.Monitors = monitors
+	})
+}
+
+// UpdateAlertContact applies an edit sent to the api to the cached alert contact with the id
+func (cache *SnapshotCache) UpdateAlertContact(id string, change func(alertContact *uptimerobot.AlertContactDetails)) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	cache.alertContacts.update(id, change)
+}
+
+// RemoveAlertContact drops the alert contact with the id once the api deleted it, along with its
+// attachments to monitors the api removed with it
+func (cache *SnapshotCache) RemoveAlertContact(id string) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	cache.alertContacts.remove(id)
+	cache.monitors.updateAll(func(monitor *uptimerobot.MonitorDetails) {
+		var alertContacts []uptimerobot.MonitorAlertContact
+		for _, alertContact := range monitor.AlertContacts {
+			if strconv.Itoa(int(alertContact.Id)) != id {
+				alertContacts = append(alertContacts, alertContact)
+			}
+		}
+		monitor.AlertContacts = alertContacts
+	})
+}
+
+// UpdateMaintenanceWindow applies an edit sent to the api to the cached maintenance window with the id
+func (cache *SnapshotCache) UpdateMaintenanceWindow(id string, change func(mwindow *uptimerobot.MWindowDetails)) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	cache.mwindows.update(id, change)
+}
+
+// RemoveMaintenanceWindow drops the maintenance window with the id once the api deleted it, along with
+// its attachments to monitors the api removed with it
+func (cache *SnapshotCache) RemoveMaintenanceWindow(id string) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	cache.mwindows.remove(id)
+	cache.monitors.updateAll(func(monitor *uptimerobot.MonitorDetails) {
+		var mwindows []uptimerobot.MonitorMWindow
+		for _, mwindow := range monitor.MaintenanceWindows {
+			if strconv.Itoa(int(mwindow.Id)) != id {
+				mwindows = append(mwindows, mwindow)
+			}
+		}
+		monitor.MaintenanceWindows = mwindows
+	})
+}
+
+// UpdateStatusPage applies an edit sent to the api to the cached status page with the id
+func (cache *SnapshotCache) UpdateStatusPage(id string, change func(psp *uptimerobot.PSPDetails)) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	cache.psps.update(id, change)
+}
+
+// RemoveStatusPage drops the status page with the id once the api deleted it
+func (cache *SnapshotCache) RemoveStatusPage(id string) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	cache.psps.remove(id)
+}
+
+// SnapshotCaches hands out one SnapshotCache per account client
+type SnapshotCaches struct {
+	mutex  sync.Mutex
+	maxAge time.Duration
+	caches map[SnapshotApiClient]*SnapshotCache
+}
+
+func NewSnapshotCaches(maxAge time.Duration) *SnapshotCaches {
+	return &SnapshotCaches{
+		maxAge: maxAge,
+		caches: map[SnapshotApiClient]*SnapshotCache{},
+	}
+}
+
+// For returns the snapshot cache of the account the client talks to
+func (caches *SnapshotCaches) For(apiClient SnapshotApiClient) *SnapshotCache {
+	caches.mutex.Lock()
+	defer caches.mutex.Unlock()
+	cache, ok := caches.caches[apiClient]
+	if !ok {
+		cache = NewSnapshotCache(apiClient, caches.maxAge)
+		caches.caches[apiClient] = cache
+	}
+
+	return cache
+}
+
+// Remove drops the snapshot cache of a client that is no longer used
+func (caches *SnapshotCaches) Remove(apiClient SnapshotApiClient) {
+	caches.mutex.Lock()
+	defer caches.mutex.Unlock()
+	delete(caches.caches, apiClient)
+}
@@ -0,0 +1,175 @@
+package urrecon
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/luckielordie/uptime-robot-operator/internal/uptimerobot"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+type StatusPageApiClient interface {
+	uptimerobot.PSPCreator
+	uptimerobot.PSPEditor
+}
+
+// StatusPageSettings are the settings of a status page the api doesn't return, changes to them are
+// detected through their Hash
+type StatusPageSettings struct {
+	CustomDomain string
+	Password     string
+	HideUrlLinks bool
+}
+
+// Hash identifies the settings without revealing the password, it is empty when nothing is set
+func (settings StatusPageSettings) Hash() string {
+	if settings == (StatusPageSettings{}) {
+		return ""
+	}
+
+	encoded, _ := json.Marshal(settings)
+	sum := sha256.Sum256(encoded)
+	return hex.EncodeToString(sum[:])
+}
+
+type StatusPage struct {
+	Id   string
+	Name string
+	// Monitors are the sorted ids of the monitors the page shows
+	Monitors []string
+	Sort     int
+	Settings StatusPageSettings
+	// SettingsHash is the Hash of the settings last sent to the api
+	SettingsHash string
+}
+
+// loggedStatusPage stops MarshalLog recursing into itself
+type loggedStatusPage StatusPage
+
+// MarshalLog keeps the password, read from a Secret, out of the logs
+func (statusPage StatusPage) MarshalLog() interface{} {
+	if statusPage.Settings.Password != "" {
+		statusPage.Settings.Password = "[redacted]"
+	}
+
+	return loggedStatusPage(statusPage)
+}
+
+type StatusPageApiReconciler struct {
+	apiClient StatusPageApiClient
+	snapshot  *SnapshotCache
+}
+
+func NewStatusPageApiReconciler(apiClient StatusPageApiClient, snapshot *SnapshotCache) StatusPageApiReconciler {
+	return StatusPageApiReconciler{
+		apiClient: apiClient,
+		snapshot:  snapshot,
+	}
+}
+
+func (reconciler *StatusPageApiReconciler) CreateApiObject(ctx context.Context, statusPage *StatusPage) error {
+	logger := log.FromContext(ctx)
+	response, err := reconciler.apiClient.NewPSP(ctx, uptimerobot.NewPSPRequest{
+		FriendlyName: statusPage.Name,
+		Monitors:     strings.Join(statusPage.Monitors, "-"),
+		CustomDomain: statusPage.Settings.CustomDomain,
+		Password:     statusPage.Settings.Password,
+		Sort:         statusPage.Sort,
+		HideUrlLinks: statusPage.Settings.HideUrlLinks,
+	})
+	if err != nil {
+		logger.Info("failed api request", "response", response)
+		return err
+	}
+
+	logger.Info("successful api request", "response", response)
+	statusPage.Id = strconv.Itoa(response.PSP.Id)
+	reconciler.snapshot.InvalidateStatusPages()
+
+	return nil
+}
+
+func (reconciler *StatusPageApiReconciler) EditApiObject(ctx context.Context, statusPage *StatusPage) error {
+	logger := log.FromContext(ctx)
+
+	response, err := reconciler.apiClient.EditPSP(ctx, uptimerobot.EditPSPRequest{
+		Id:           statusPage.Id,
+		FriendlyName: statusPage.Name,
+		Monitors:     strings.Join(statusPage.Monitors, "-"),
+		CustomDomain: statusPage.Settings.CustomDomain,
+		Password:     statusPage.Settings.Password,
+		Sort:         statusPage.Sort,
+		HideUrlLinks: statusPage.Settings.HideUrlLinks,
+	})
+	if err != nil {
+		logger.Info("failed api request", "response", response)
+		return err
+	}
+	logger.Info("successful api request", "response", response)
+
+	monitors := uptimerobot.PSPMonitors{}
+	for _, id := range statusPage.Monitors {
+		idInt, err := strconv.Atoi(id)
+		if err != nil {
+			reconciler.snapshot.InvalidateStatusPages()
+			return nil
+		}
+		monitors = append(monitors, idInt)
+	}
+	reconciler.snapshot.UpdateStatusPage(statusPage.Id, func(apiStatusPage *uptimerobot.PSPDetails) {
+		apiStatusPage.FriendlyName = statusPage.Name
+		apiStatusPage.Monitors = monitors
+		apiStatusPage.Sort = uptimerobot.OptionalInt(statusPage.Sort)
+	})
+
+	return nil
+}
+
+func (reconciler *StatusPageApiReconciler) ApiObjectExists(ctx context.Context, statusPage *StatusPage) (bool, error) {
+	// if id is an empty string then the object can't exist
+	if statusPage.Id == "" {
+		return false, nil
+	}
+
+	//check if object exists in the account snapshot
+	statusPages, err := reconciler.snapshot.StatusPages(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	_, exists := statusPages[statusPage.Id]
+	return exists, nil
+}
+
+func (reconciler *StatusPageApiReconciler) GetApiObject(ctx context.Context, statusPage *StatusPage) (*StatusPage, error) {
+	statusPages, err := reconciler.snapshot.StatusPages(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unexpected error with status page api: %w", err)
+	}
+
+	apiStatusPage, ok := statusPages[statusPage.Id]
+	if !ok {
+		return nil, fmt.Errorf("api returned no status page with id %s when one was expected", statusPage.Id)
+	}
+
+	remote := &StatusPage{
+		Id:           strconv.Itoa(int(apiStatusPage.Id)),
+		Name:         apiStatusPage.FriendlyName,
+		Sort:         int(apiStatusPage.Sort),
+		Settings:     statusPage.Settings,     //the settings can't be read back from the api...
+		SettingsHash: statusPage.SettingsHash, //...so compare the hash of what was last sent instead
+	}
+
+	for _, id := range apiStatusPage.Monitors {
+		remote.Monitors = append(remote.Monitors, strconv.Itoa(id))
+	}
+	sort.Strings(remote.Monitors)
+
+	return remote, nil
+}
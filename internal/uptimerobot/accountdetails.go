@@ -14,6 +14,13 @@ type GetAccountDetailsResponse struct {
 	} `json:"account"`
 }
 
+// SupportsAlertThresholds tells whether the account's plan stores the threshold and recurrence of alert
+// contacts, an advanced notification only paid plans have. getAccountDetails doesn't name the plan, paid
+// plans are told apart by their monitor limit like their rate limit is.
+func (c GetAccountDetailsResponse) SupportsAlertThresholds() bool {
+	return c.Account.MonitorLimit > freePlanMonitorLimit
+}
+
 func (c GetAccountDetailsResponse) GetStat() string {
 	return c.Stat
 }
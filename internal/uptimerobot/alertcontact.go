@@ -17,18 +17,20 @@ type AlertContactCreator interface {
 	NewAlertContact(ctx context.Context, alertType string, value string, friendlyName string) (NewAlertContactResponse, error)
 }
 
+type AlertContactDetails struct {
+	Id           string `json:"id"`
+	FriendlyName string `json:"friendly_name"`
+	Type         int    `json:"type"`
+	Status       int    `json:"status"`
+	Value        string `json:"value"`
+}
+
 type GetAlertContactResponse struct {
-	Stat          string `json:"stat"`
-	Limit         int    `json:"limit"`
-	Offset        int    `json:"offset"`
-	Total         int    `json:"total"`
-	AlertContacts []struct {
-		Id           string `json:"id"`
-		FriendlyName string `json:"friendly_name"`
-		Type         int    `json:"type"`
-		Status       int    `json:"status"`
-		Value        string `json:"value"`
-	} `json:"alert_contacts"`
+	Stat          string                `json:"stat"`
+	Limit         int                   `json:"limit"`
+	Offset        int                   `json:"offset"`
+	Total         int                   `json:"total"`
+	AlertContacts []AlertContactDetails `json:"alert_contacts"`
 }
 
 func (c GetAlertContactResponse) GetStat() string {
@@ -39,6 +41,16 @@ type AlertContactGetter interface {
 	GetAlertContacts(ctx context.Context, alertContactIds []string) (GetAlertContactResponse, error)
 }
 
+// ListAlertContactsOptions filters the alert contacts returned by getAlertContacts
+type ListAlertContactsOptions struct {
+	AlertContactIds []string
+}
+
+type AlertContactLister interface {
+	GetAlertContactsPage(ctx context.Context, options ListAlertContactsOptions, offset int, limit int) (GetAlertContactResponse, error)
+	ListAllAlertContacts(ctx context.Context, options ListAlertContactsOptions, visit func(alertContact AlertContactDetails) error) error
+}
+
 type EditAlertContactResponse struct {
 	Stat         string `json:"stat"`
 	AlertContact struct {
@@ -0,0 +1,5 @@
+package uptimerobot
+
+type ApiKeySetter interface {
+	SetApiKey(apiKey string)
+}
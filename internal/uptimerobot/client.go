@@ -3,59 +3,173 @@ package uptimerobot
 import (
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
 )
 
 type Client struct {
-	apiKey string
+	mutex            sync.RWMutex
+	apiKey           string
+	baseUrl          string
+	userAgent        string
+	httpClient       *http.Client
+	limiter          *rate.Limiter
+	maxRateLimitWait time.Duration
+	blockedUntil     time.Time
+	retryPolicy      RetryPolicy
+}
+
+type apiResponse struct {
+	statusCode int
+	header     http.Header
+	body       []byte
 }
 
 type apiRequester interface {
-	makeApiRequest(ctx context.Context, methodName string, params map[string]string) ([]byte, error)
+	makeApiRequest(ctx context.Context, methodName string, params map[string]string) (apiResponse, error)
 }
 
-func NewClient(apiKey string) Client {
-	return Client{
-		apiKey: apiKey,
+func NewClient(apiKey string, options ...ClientOption) (*Client, error) {
+	clientOptions := newClientOptions(options)
+	baseUrl, err := parseBaseUrl(clientOptions.baseUrl)
+	if err != nil {
+		return nil, err
+	}
+
+	httpClient, err := buildHttpClient(clientOptions)
+	if err != nil {
+		return nil, err
 	}
+
+	rateLimit := clientOptions.rateLimit
+	if rateLimit.RequestsPerMinute <= 0 {
+		rateLimit = FreePlanRateLimit
+	}
+
+	return &Client{
+		apiKey:           apiKey,
+		baseUrl:          baseUrl,
+		userAgent:        clientOptions.userAgent,
+		httpClient:       httpClient,
+		limiter:          rate.NewLimiter(rateLimit.limit(), rateLimit.burst()),
+		maxRateLimitWait: clientOptions.maxRateLimitWait,
+		retryPolicy:      clientOptions.retryPolicy,
+	}, nil
+}
+
+// SetApiKey swaps the api key used by all subsequent requests made with this client
+func (client *Client) SetApiKey(apiKey string) {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+	client.apiKey = apiKey
+}
+
+func (client *Client) getApiKey() string {
+	client.mutex.RLock()
+	defer client.mutex.RUnlock()
+	return client.apiKey
+}
+
+// String keeps the api key out of logs and error messages that format the client
+func (client *Client) String() string {
+	return fmt.Sprintf("uptimerobot.Client{baseUrl: %q, apiKey: %q}", client.baseUrl, "[redacted]")
+}
+
+// GoString keeps the api key out of %#v formatting
+func (client *Client) GoString() string {
+	return client.String()
 }
 
-func (client Client) makeApiRequest(ctx context.Context, methodName string, params map[string]string) ([]byte, error) {
-	url := fmt.Sprintf("https://api.uptimerobot.com/v2/%s", methodName)
-	payloadString := fmt.Sprintf("api_key=%s&format=json", client.apiKey)
-	if len(params) > 0 {
-		for key, value := range params {
-			payloadString = fmt.Sprintf("%s&%s=%s", payloadString, key, value)
+// makeApiRequest calls an api method, retrying transient failures according to the client's retry policy
+func (client *Client) makeApiRequest(ctx context.Context, methodName string, params map[string]string) (apiResponse, error) {
+	attempts := client.retryPolicy.MaxAttempts
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	var response apiResponse
+	var err error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		if attempt > 1 {
+			err := sleep(ctx, client.retryPolicy.backoff(attempt-1))
+			if err != nil {
+				return apiResponse{}, err
+			}
 		}
+
+		response, err = client.doApiRequest(ctx, methodName, params)
+		if err != nil {
+			if !shouldRetryError(ctx, methodName, err) {
+				return apiResponse{}, err
+			}
+
+			continue
+		}
+
+		if !shouldRetryResponse(methodName, response) {
+			return response, nil
+		}
+	}
+
+	return response, err
+}
+
+func (client *Client) doApiRequest(ctx context.Context, methodName string, params map[string]string) (apiResponse, error) {
+	requestUrl := fmt.Sprintf("%s%s", client.baseUrl, methodName)
+	form := url.Values{}
+	for key, value := range params {
+		form.Set(key, value)
 	}
+	form.Set("api_key", client.getApiKey())
+	form.Set("format", "json")
 
-	payload := strings.NewReader(payloadString)
+	payload := strings.NewReader(form.Encode())
+
+	err := client.waitForRateLimit(ctx)
+	if err != nil {
+		return apiResponse{}, err
+	}
 
-	request, err := http.NewRequestWithContext(ctx, "POST", url, payload)
+	request, err := http.NewRequestWithContext(ctx, "POST", requestUrl, payload)
 	if err != nil {
-		return []byte{}, err
+		return apiResponse{}, err
 	}
 
 	request.Header.Add("cache-control", "no-cache")
 	request.Header.Add("content-type", "application/x-www-form-urlencoded")
+	if client.userAgent != "" {
+		request.Header.Add("user-agent", client.userAgent)
+	}
 
-	response, err := http.DefaultClient.Do(request)
+	response, err := client.httpClient.Do(request)
 	if err != nil {
-		return []byte{}, err
+		return apiResponse{}, err
 	}
 
 	defer response.Body.Close()
+	err = client.observeRateLimitHeaders(response)
+	if err != nil {
+		return apiResponse{}, err
+	}
+
 	bodyBytes, err := io.ReadAll(response.Body)
 	if err != nil {
-		return []byte{}, err
+		return apiResponse{}, err
 	}
 
-	return bodyBytes, nil
+	return apiResponse{
+		statusCode: response.StatusCode,
+		header:     response.Header,
+		body:       bodyBytes,
+	}, nil
 }
 
 type APIResponse interface {
@@ -68,26 +182,30 @@ func request[Response APIResponse](ctx context.Context, apiCall string, requeste
 		return *new(Response), err
 	}
 
-	responseBytes, err := requester.makeApiRequest(ctx, apiCall, params)
+	rawResponse, err := requester.makeApiRequest(ctx, apiCall, params)
 	if err != nil {
 		return *new(Response), err
 	}
 
-	var response Response
-	err = json.Unmarshal(responseBytes, &response)
+	err = checkResponse(rawResponse)
 	if err != nil {
 		return *new(Response), err
 	}
 
-	stat := response.GetStat()
-	if stat == "fail" {
-		return *new(Response), errors.New(string(responseBytes))
+	var response Response
+	err = json.Unmarshal(rawResponse.body, &response)
+	if err != nil {
+		return *new(Response), newResponseError(fmt.Sprintf("failed to decode %s response: %s", apiCall, err), rawResponse)
+	}
+
+	if response.GetStat() != "ok" {
+		return *new(Response), newResponseError(fmt.Sprintf("unexpected stat %q", response.GetStat()), rawResponse)
 	}
 
 	return response, nil
 }
 
-func (client Client) GetAccountDetails(ctx context.Context) (GetAccountDetailsResponse, error) {
+func (client *Client) GetAccountDetails(ctx context.Context) (GetAccountDetailsResponse, error) {
 	response, err := request[GetAccountDetailsResponse](ctx, "getAccountDetails", client, func() (map[string]string, error) {
 		return map[string]string{}, nil
 	})
@@ -95,7 +213,7 @@ func (client Client) GetAccountDetails(ctx context.Context) (GetAccountDetailsRe
 	return response, err
 }
 
-func (client Client) DeleteAlertContact(ctx context.Context, id string) (DeleteAlertContactResponse, error) {
+func (client *Client) DeleteAlertContact(ctx context.Context, id string) (DeleteAlertContactResponse, error) {
 	response, err := request[DeleteAlertContactResponse](ctx, "deleteAlertContact", client, func() (map[string]string, error) {
 		return map[string]string{
 			"id": id,
@@ -105,7 +223,7 @@ func (client Client) DeleteAlertContact(ctx context.Context, id string) (DeleteA
 	return response, err
 }
 
-func (client Client) EditAlertContact(ctx context.Context, id string, value string, friendlyName string) (EditAlertContactResponse, error) {
+func (client *Client) EditAlertContact(ctx context.Context, id string, value string, friendlyName string) (EditAlertContactResponse, error) {
 	response, err := request[EditAlertContactResponse](ctx, "editAlertContact", client, func() (map[string]string, error) {
 		params := map[string]string{
 			"id":    id,
@@ -122,7 +240,7 @@ func (client Client) EditAlertContact(ctx context.Context, id string, value stri
 	return response, err
 }
 
-func (client Client) GetAlertContacts(ctx context.Context, alertContactIds []string) (GetAlertContactResponse, error) {
+func (client *Client) GetAlertContacts(ctx context.Context, alertContactIds []string) (GetAlertContactResponse, error) {
 	response, err := request[GetAlertContactResponse](ctx, "getAlertContacts", client, func() (map[string]string, error) {
 		params := map[string]string{}
 		for _, id := range alertContactIds {
@@ -139,7 +257,7 @@ func (client Client) GetAlertContacts(ctx context.Context, alertContactIds []str
 	return response, err
 }
 
-func (client Client) NewAlertContact(ctx context.Context, alertType string, value string, friendlyName string) (NewAlertContactResponse, error) {
+func (client *Client) NewAlertContact(ctx context.Context, alertType string, value string, friendlyName string) (NewAlertContactResponse, error) {
 	response, err := request[NewAlertContactResponse](ctx, "newAlertContact", client, func() (map[string]string, error) {
 		params := map[string]string{
 			"type":  alertType,
@@ -154,7 +272,7 @@ func (client Client) NewAlertContact(ctx context.Context, alertType string, valu
 	return response, err
 }
 
-func (client Client) NewMonitor(ctx context.Context, req NewMonitorRequest) (NewMonitorResponse, error) {
+func (client *Client) NewMonitor(ctx context.Context, req NewMonitorRequest) (NewMonitorResponse, error) {
 	response, err := request[NewMonitorResponse](ctx, "newMonitor", client, func() (map[string]string, error) {
 		params := map[string]string{
 			"friendly_name": req.FriendlyName,
@@ -165,7 +283,7 @@ func (client Client) NewMonitor(ctx context.Context, req NewMonitorRequest) (New
 		params = IfIntSetAddParam("sub_type", req.SubType, params)
 		params = IfIntSetAddParam("port", req.Port, params)
 		params = IfIntSetAddParam("keyword_type", req.KeywordType, params)
-		params = IfIntSetAddParam("keyword_case_type", req.KeywordCaseType, params)
+		params = ifKeywordSetAddCaseType(req.KeywordType, req.KeywordCaseType, params)
 		params = IfStringSetAddParam("keyword_value", req.KeywordValue, params)
 		params = IfIntSetAddParam("interval", req.Interval, params)
 		params = IfIntSetAddParam("timeout", req.Timeout, params)
@@ -188,6 +306,9 @@ func (client Client) NewMonitor(ctx context.Context, req NewMonitorRequest) (New
 		params = IfStringSetAddParam("mwindows", req.MaintenanceWindows, params)
 		params = IfStringSetAddParam("custom_http_headers", req.CustomHttpHeaders, params)
 		params = IfStringSetAddParam("custom_http_statuses", req.CustomHttpStatuses, params)
+		params = IfBoolSetAddParam("ignore_ssl_errors", req.IgnoreSSLErrors, params)
+		params = IfBoolSetAddParam("ssl_expiration_reminder", req.SSLExpirationReminder, params)
+		params = IfBoolSetAddParam("disable_domain_expire_notifications", req.DisableDomainExpireNotifications, params)
 
 		return params, nil
 	})
@@ -195,7 +316,7 @@ func (client Client) NewMonitor(ctx context.Context, req NewMonitorRequest) (New
 	return response, err
 }
 
-func (client Client) DeleteMonitor(ctx context.Context, id int) (DeleteMonitorResponse, error) {
+func (client *Client) DeleteMonitor(ctx context.Context, id int) (DeleteMonitorResponse, error) {
 	response, err := request[DeleteMonitorResponse](ctx, "deleteMonitor", client, func() (map[string]string, error) {
 		params := map[string]string{
 			"id": strconv.Itoa(id),
@@ -207,7 +328,7 @@ func (client Client) DeleteMonitor(ctx context.Context, id int) (DeleteMonitorRe
 	return response, err
 }
 
-func (client Client) EditMonitor(ctx context.Context, req EditMonitorRequest) (EditMonitorResponse, error) {
+func (client *Client) EditMonitor(ctx context.Context, req EditMonitorRequest) (EditMonitorResponse, error) {
 	response, err := request[EditMonitorResponse](ctx, "editMonitor", client, func() (map[string]string, error) {
 		params := map[string]string{
 			"id": req.Id,
@@ -218,29 +339,35 @@ func (client Client) EditMonitor(ctx context.Context, req EditMonitorRequest) (E
 		params = IfIntSetAddParam("sub_type", req.SubType, params)
 		params = IfIntSetAddParam("port", req.Port, params)
 		params = IfIntSetAddParam("keyword_type", req.KeywordType, params)
-		params = IfIntSetAddParam("keyword_case_type", req.KeywordCaseType, params)
+		params = ifKeywordSetAddCaseType(req.KeywordType, req.KeywordCaseType, params)
 		params = IfStringSetAddParam("keyword_value", req.KeywordValue, params)
 		params = IfIntSetAddParam("interval", req.Interval, params)
 		params = IfIntSetAddParam("timeout", req.Timeout, params)
-		params = IfStringSetAddParam("http_username", req.HttpUsername, params)
-		params = IfStringSetAddParam("http_password", req.HttpPassword, params)
-		params = IfIntSetAddParam("http_auth_type", req.HttpAuthType, params)
-		params = IfIntSetAddParam("post_type", req.PostType, params)
-		params = IfStringSetAddParam("post_value", req.PostValue, params)
-		params = IfStringSetAddParam("http_method", req.HttpMethod, params)
-		params = IfIntSetAddParam("post_content_type", req.PostContentType, params)
-
-		for _, id := range req.AlertContacts {
-			if params["alert_contacts"] == "" {
-				params["alert_contacts"] = fmt.Sprint(id)
-			} else {
-				params["alert_contacts"] = fmt.Sprintf("%s-%s", params["alert_contacts"], id)
-			}
+		if req.Status != nil {
+			params["status"] = strconv.Itoa(*req.Status)
+		}
+		//the http settings are reset to the api's defaults when unset, leaving them out keeps the old values.
+		//only http and keyword monitors have them.
+		if req.MonitorType == MonitorTypeHttp || req.MonitorType == MonitorTypeKeyword {
+			params = AddStringParam("http_username", req.HttpUsername, "", params)
+			params = AddStringParam("http_password", req.HttpPassword, "", params)
+			params = AddIntParam("http_auth_type", req.HttpAuthType, HttpAuthTypeBasic, params)
+			params = AddIntParam("post_type", req.PostType, PostTypeKeyValue, params)
+			params = AddStringParam("post_value", req.PostValue, "", params)
+			params = AddStringParam("http_method", req.HttpMethod, strconv.Itoa(HttpMethodHead), params)
+			params = AddIntParam("post_content_type", req.PostContentType, PostContentTypeHtml, params)
+			params = AddStringParam("custom_http_headers", req.CustomHttpHeaders, "{}", params)
+			params = AddStringParam("custom_http_statuses", req.CustomHttpStatuses, "", params)
 		}
 
-		params = IfStringSetAddParam("mwindows", req.MaintenanceWindows, params)
-		params = IfStringSetAddParam("custom_http_headers", req.CustomHttpHeaders, params)
-		params = IfStringSetAddParam("custom_http_statuses", req.CustomHttpStatuses, params)
+		//an empty alert_contacts detaches every contact
+		params["alert_contacts"] = strings.Join(req.AlertContacts, "-")
+		//an empty mwindows detaches every window
+		params["mwindows"] = req.MaintenanceWindows
+		//an edit has to turn these off explicitly, leaving them out keeps them on
+		params = AddBoolParam("ignore_ssl_errors", req.IgnoreSSLErrors, params)
+		params = AddBoolParam("ssl_expiration_reminder", req.SSLExpirationReminder, params)
+		params = AddBoolParam("disable_domain_expire_notifications", req.DisableDomainExpireNotifications, params)
 
 		return params, nil
 	})
@@ -248,7 +375,7 @@ func (client Client) EditMonitor(ctx context.Context, req EditMonitorRequest) (E
 	return response, err
 }
 
-func (client Client) GetMonitors(ctx context.Context, monitorIds []string) (GetMonitorResponse, error) {
+func (client *Client) GetMonitors(ctx context.Context, monitorIds []string) (GetMonitorResponse, error) {
 	response, err := request[GetMonitorResponse](ctx, "getMonitors", client, func() (map[string]string, error) {
 		params := map[string]string{}
 		for _, id := range monitorIds {
@@ -273,6 +400,16 @@ func IfIntSetAddParam(paramString string, value int, params map[string]string) m
 	return params
 }
 
+// ifKeywordSetAddCaseType always sends keyword_case_type for keyword monitors because
+// its zero value, case sensitive, is meaningful
+func ifKeywordSetAddCaseType(keywordType int, keywordCaseType int, params map[string]string) map[string]string {
+	if keywordType != 0 {
+		params["keyword_case_type"] = strconv.Itoa(keywordCaseType)
+	}
+
+	return params
+}
+
 func IfStringSetAddParam(paramString string, value string, params map[string]string) map[string]string {
 	if value != "" {
 		params[paramString] = value
@@ -280,3 +417,335 @@ func IfStringSetAddParam(paramString string, value string, params map[string]str
 
 	return params
 }
+
+// MaxPageSize is the most objects the api returns from a single list request
+const MaxPageSize = 50
+
+func joinInts(values []int) string {
+	strs := make([]string, len(values))
+	for i, value := range values {
+		strs[i] = strconv.Itoa(value)
+	}
+
+	return strings.Join(strs, "-")
+}
+
+func IfBoolSetAddParam(paramString string, value bool, params map[string]string) map[string]string {
+	if value {
+		params[paramString] = "1"
+	}
+
+	return params
+}
+
+// AddStringParam always sends the string, defaultValue in its place when it is empty
+func AddStringParam(paramString string, value string, defaultValue string, params map[string]string) map[string]string {
+	params[paramString] = defaultValue
+	if value != "" {
+		params[paramString] = value
+	}
+
+	return params
+}
+
+// AddIntParam always sends the int, defaultValue in its place when it is 0
+func AddIntParam(paramString string, value int, defaultValue int, params map[string]string) map[string]string {
+	params[paramString] = strconv.Itoa(defaultValue)
+	if value != 0 {
+		params[paramString] = strconv.Itoa(value)
+	}
+
+	return params
+}
+
+// AddBoolParam always sends the bool, as 1 or 0
+func AddBoolParam(paramString string, value bool, params map[string]string) map[string]string {
+	params[paramString] = "0"
+	if value {
+		params[paramString] = "1"
+	}
+
+	return params
+}
+
+func (options ListMonitorsOptions) params() map[string]string {
+	params := map[string]string{}
+	params = IfStringSetAddParam("monitors", strings.Join(options.MonitorIds, "-"), params)
+	params = IfStringSetAddParam("types", joinInts(options.Types), params)
+	params = IfStringSetAddParam("statuses", joinInts(options.Statuses), params)
+	params = IfStringSetAddParam("search", options.Search, params)
+	params = IfBoolSetAddParam("logs", options.IncludeLogs, params)
+	if options.IncludeLogs {
+		params = IfIntSetAddParam("logs_limit", options.LogsLimit, params)
+	}
+	params = IfBoolSetAddParam("alert_contacts", options.IncludeAlertContacts, params)
+	params = IfBoolSetAddParam("mwindows", options.IncludeMaintenanceWindows, params)
+	params = IfBoolSetAddParam("ssl", options.IncludeSSL, params)
+	params = IfBoolSetAddParam("custom_http_headers", options.IncludeCustomHttpHeaders, params)
+	params = IfBoolSetAddParam("custom_http_statuses", options.IncludeCustomHttpStatuses, params)
+	params = IfBoolSetAddParam("all_time_uptime_ratio", options.IncludeAllTimeUptimeRatio, params)
+	params = IfBoolSetAddParam("response_times", options.IncludeResponseTimes, params)
+	if options.IncludeResponseTimes {
+		params = IfIntSetAddParam("response_times_limit", options.ResponseTimesLimit, params)
+	}
+	params = IfStringSetAddParam("custom_uptime_ratios", joinInts(options.CustomUptimeRatios), params)
+
+	return params
+}
+
+func (client *Client) GetMonitorsPage(ctx context.Context, options ListMonitorsOptions, offset int, limit int) (GetMonitorResponse, error) {
+	response, err := request[GetMonitorResponse](ctx, "getMonitors", client, func() (map[string]string, error) {
+		params := options.params()
+		params = IfIntSetAddParam("offset", offset, params)
+		params = IfIntSetAddParam("limit", limit, params)
+
+		return params, nil
+	})
+	if err != nil {
+		return response, err
+	}
+
+	for i := range response.Monitors {
+		response.Monitors[i].UptimeRatios = splitUptimeRatios(options.CustomUptimeRatios, string(response.Monitors[i].CustomUptimeRatio))
+	}
+
+	return response, nil
+}
+
+// splitUptimeRatios pairs the ratios getMonitors joins with "-" with the periods they were requested for
+func splitUptimeRatios(periods []int, ratios string) map[int]string {
+	if len(periods) == 0 || ratios == "" {
+		return nil
+	}
+
+	values := strings.Split(ratios, "-")
+	uptimeRatios := make(map[int]string, len(values))
+	for i, period := range periods {
+		if i >= len(values) {
+			break
+		}
+		uptimeRatios[period] = values[i]
+	}
+
+	return uptimeRatios
+}
+
+// ListAllMonitors walks every page of getMonitors, calling visit for each monitor in turn
+func (client *Client) ListAllMonitors(ctx context.Context, options ListMonitorsOptions, visit func(monitor MonitorDetails) error) error {
+	offset := 0
+	for {
+		response, err := client.GetMonitorsPage(ctx, options, offset, MaxPageSize)
+		if err != nil {
+			return err
+		}
+
+		for _, monitor := range response.Monitors {
+			err = visit(monitor)
+			if err != nil {
+				return err
+			}
+		}
+
+		offset += len(response.Monitors)
+		if len(response.Monitors) == 0 || offset >= response.Pagination.Total {
+			return nil
+		}
+	}
+}
+
+func (client *Client) GetAlertContactsPage(ctx context.Context, options ListAlertContactsOptions, offset int, limit int) (GetAlertContactResponse, error) {
+	response, err := request[GetAlertContactResponse](ctx, "getAlertContacts", client, func() (map[string]string, error) {
+		params := map[string]string{}
+		params = IfStringSetAddParam("alert_contacts", strings.Join(options.AlertContactIds, "-"), params)
+		params = IfIntSetAddParam("offset", offset, params)
+		params = IfIntSetAddParam("limit", limit, params)
+
+		return params, nil
+	})
+
+	return response, err
+}
+
+// ListAllAlertContacts walks every page of getAlertContacts, calling visit for each alert contact in turn
+func (client *Client) ListAllAlertContacts(ctx context.Context, options ListAlertContactsOptions, visit func(alertContact AlertContactDetails) error) error {
+	offset := 0
+	for {
+		response, err := client.GetAlertContactsPage(ctx, options, offset, MaxPageSize)
+		if err != nil {
+			return err
+		}
+
+		for _, alertContact := range response.AlertContacts {
+			err = visit(alertContact)
+			if err != nil {
+				return err
+			}
+		}
+
+		offset += len(response.AlertContacts)
+		if len(response.AlertContacts) == 0 || offset >= response.Total {
+			return nil
+		}
+	}
+}
+
+func (client *Client) NewMWindow(ctx context.Context, req NewMWindowRequest) (NewMWindowResponse, error) {
+	response, err := request[NewMWindowResponse](ctx, "newMWindow", client, func() (map[string]string, error) {
+		params := map[string]string{
+			"friendly_name": req.FriendlyName,
+			"type":          strconv.Itoa(req.Type),
+			"start_time":    req.StartTime,
+			"duration":      strconv.Itoa(req.Duration),
+		}
+		params = IfStringSetAddParam("value", req.Value, params)
+
+		return params, nil
+	})
+
+	return response, err
+}
+
+func (client *Client) EditMWindow(ctx context.Context, req EditMWindowRequest) (EditMWindowResponse, error) {
+	response, err := request[EditMWindowResponse](ctx, "editMWindow", client, func() (map[string]string, error) {
+		params := map[string]string{
+			"id": req.Id,
+		}
+		params = IfStringSetAddParam("friendly_name", req.FriendlyName, params)
+		params = IfStringSetAddParam("value", req.Value, params)
+		params = IfStringSetAddParam("start_time", req.StartTime, params)
+		params = IfIntSetAddParam("duration", req.Duration, params)
+
+		return params, nil
+	})
+
+	return response, err
+}
+
+func (client *Client) DeleteMWindow(ctx context.Context, id string) (DeleteMWindowResponse, error) {
+	response, err := request[DeleteMWindowResponse](ctx, "deleteMWindow", client, func() (map[string]string, error) {
+		return map[string]string{
+			"id": id,
+		}, nil
+	})
+
+	return response, err
+}
+
+func (client *Client) GetMWindowsPage(ctx context.Context, options ListMWindowsOptions, offset int, limit int) (GetMWindowsResponse, error) {
+	response, err := request[GetMWindowsResponse](ctx, "getMWindows", client, func() (map[string]string, error) {
+		params := map[string]string{}
+		params = IfStringSetAddParam("mwindows", strings.Join(options.MWindowIds, "-"), params)
+		params = IfIntSetAddParam("offset", offset, params)
+		params = IfIntSetAddParam("limit", limit, params)
+
+		return params, nil
+	})
+
+	return response, err
+}
+
+// ListAllMWindows walks every page of getMWindows, calling visit for each maintenance window in turn
+func (client *Client) ListAllMWindows(ctx context.Context, options ListMWindowsOptions, visit func(mwindow MWindowDetails) error) error {
+	offset := 0
+	for {
+		response, err := client.GetMWindowsPage(ctx, options, offset, MaxPageSize)
+		if err != nil {
+			return err
+		}
+
+		for _, mwindow := range response.MWindows {
+			err = visit(mwindow)
+			if err != nil {
+				return err
+			}
+		}
+
+		offset += len(response.MWindows)
+		if len(response.MWindows) == 0 || offset >= response.Pagination.Total {
+			return nil
+		}
+	}
+}
+
+func (client *Client) NewPSP(ctx context.Context, req NewPSPRequest) (NewPSPResponse, error) {
+	response, err := request[NewPSPResponse](ctx, "newPSP", client, func() (map[string]string, error) {
+		params := map[string]string{
+			"type":          strconv.Itoa(PSPTypeMonitors),
+			"friendly_name": req.FriendlyName,
+			"monitors":      req.Monitors,
+		}
+		params = IfStringSetAddParam("custom_domain", req.CustomDomain, params)
+		params = IfStringSetAddParam("password", req.Password, params)
+		params = IfIntSetAddParam("sort", req.Sort, params)
+		params = IfBoolSetAddParam("hide_url_links", req.HideUrlLinks, params)
+
+		return params, nil
+	})
+
+	return response, err
+}
+
+func (client *Client) EditPSP(ctx context.Context, req EditPSPRequest) (EditPSPResponse, error) {
+	response, err := request[EditPSPResponse](ctx, "editPSP", client, func() (map[string]string, error) {
+		params := map[string]string{
+			"id": req.Id,
+		}
+		params = IfStringSetAddParam("friendly_name", req.FriendlyName, params)
+		params = IfStringSetAddParam("monitors", req.Monitors, params)
+		//an edit has to clear these explicitly, leaving them out keeps them set
+		params["custom_domain"] = req.CustomDomain
+		params["password"] = req.Password
+		params = IfIntSetAddParam("sort", req.Sort, params)
+		params = AddBoolParam("hide_url_links", req.HideUrlLinks, params)
+
+		return params, nil
+	})
+
+	return response, err
+}
+
+func (client *Client) DeletePSP(ctx context.Context, id string) (DeletePSPResponse, error) {
+	response, err := request[DeletePSPResponse](ctx, "deletePSP", client, func() (map[string]string, error) {
+		return map[string]string{
+			"id": id,
+		}, nil
+	})
+
+	return response, err
+}
+
+func (client *Client) GetPSPsPage(ctx context.Context, options ListPSPsOptions, offset int, limit int) (GetPSPsResponse, error) {
+	response, err := request[GetPSPsResponse](ctx, "getPSPs", client, func() (map[string]string, error) {
+		params := map[string]string{}
+		params = IfStringSetAddParam("psps", strings.Join(options.PSPIds, "-"), params)
+		params = IfIntSetAddParam("offset", offset, params)
+		params = IfIntSetAddParam("limit", limit, params)
+
+		return params, nil
+	})
+
+	return response, err
+}
+
+// ListAllPSPs walks every page of getPSPs, calling visit for each status page in turn
+func (client *Client) ListAllPSPs(ctx context.Context, options ListPSPsOptions, visit func(psp PSPDetails) error) error {
+	offset := 0
+	for {
+		response, err := client.GetPSPsPage(ctx, options, offset, MaxPageSize)
+		if err != nil {
+			return err
+		}
+
+		for _, psp := range response.PSPs {
+			err = visit(psp)
+			if err != nil {
+				return err
+			}
+		}
+
+		offset += len(response.PSPs)
+		if len(response.PSPs) == 0 || offset >= response.Pagination.Total {
+			return nil
+		}
+	}
+}
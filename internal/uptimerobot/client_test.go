@@ -0,0 +1,779 @@
+package uptimerobot
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+const hostileValue = `a&b=c+d e%f#g?h;i/j"k'l{"m":[1]}`
+
+// newTestServer starts a stand-in api that records the form of every request and replies with body
+func newTestServer(t *testing.T, body string) (*httptest.Server, *[]url.Values) {
+	t.Helper()
+	var forms []url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if err := request.ParseForm(); err != nil {
+			t.Errorf("failed to parse form: %v", err)
+		}
+		forms = append(forms, request.PostForm)
+		writer.Header().Set("content-type", "application/json")
+		fmt.Fprint(writer, body)
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &forms
+}
+
+func newTestClient(t *testing.T, server *httptest.Server, apiKey string) *Client {
+	t.Helper()
+	client, err := NewClient(apiKey, WithBaseUrl(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	return client
+}
+
+func TestNewMonitorEncodesParams(t *testing.T) {
+	testCases := []struct {
+		param    string
+		expected string
+		request  NewMonitorRequest
+	}{
+		{"friendly_name", hostileValue, NewMonitorRequest{FriendlyName: hostileValue}},
+		{"url", "https://example.com/path?a=1&b=2+3", NewMonitorRequest{Url: "https://example.com/path?a=1&b=2+3"}},
+		{"type", "2", NewMonitorRequest{MonitorType: 2}},
+		{"sub_type", "99", NewMonitorRequest{SubType: 99}},
+		{"port", "8443", NewMonitorRequest{Port: 8443}},
+		{"keyword_type", "1", NewMonitorRequest{KeywordType: 1}},
+		{"keyword_case_type", "1", NewMonitorRequest{KeywordType: 1, KeywordCaseType: 1}},
+		{"keyword_case_type", "0", NewMonitorRequest{KeywordType: 2, KeywordCaseType: 0}},
+		{"keyword_value", hostileValue, NewMonitorRequest{KeywordValue: hostileValue}},
+		{"interval", "300", NewMonitorRequest{Interval: 300}},
+		{"timeout", "30", NewMonitorRequest{Timeout: 30}},
+		{"http_username", hostileValue, NewMonitorRequest{HttpUsername: hostileValue}},
+		{"http_password", hostileValue, NewMonitorRequest{HttpPassword: hostileValue}},
+		{"http_auth_type", "2", NewMonitorRequest{HttpAuthType: 2}},
+		{"post_type", "1", NewMonitorRequest{PostType: 1}},
+		{"post_value", hostileValue, NewMonitorRequest{PostValue: hostileValue}},
+		{"http_method", "POST", NewMonitorRequest{HttpMethod: "POST"}},
+		{"post_content_type", "1", NewMonitorRequest{PostContentType: 1}},
+		{"alert_contacts", "1_0_0-2_5_10", NewMonitorRequest{AlertContacts: []string{"1_0_0", "2_5_10"}}},
+		{"mwindows", "1-2-3", NewMonitorRequest{MaintenanceWindows: "1-2-3"}},
+		{"custom_http_headers", `{"Authorization":"Bearer a+b/c=","X-Query":"a&b=c"}`, NewMonitorRequest{CustomHttpHeaders: `{"Authorization":"Bearer a+b/c=","X-Query":"a&b=c"}`}},
+		{"custom_http_statuses", "404:1_200:0", NewMonitorRequest{CustomHttpStatuses: "404:1_200:0"}},
+		{"ignore_ssl_errors", "1", NewMonitorRequest{IgnoreSSLErrors: true}},
+		{"ssl_expiration_reminder", "1", NewMonitorRequest{SSLExpirationReminder: true}},
+		{"disable_domain_expire_notifications", "1", NewMonitorRequest{DisableDomainExpireNotifications: true}},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.param, func(t *testing.T) {
+			server, forms := newTestServer(t, `{"stat":"ok","monitor":{"id":1,"status":1}}`)
+			client := newTestClient(t, server, "key")
+
+			_, err := client.NewMonitor(context.Background(), testCase.request)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(*forms) != 1 {
+				t.Fatalf("expected 1 request, got %d", len(*forms))
+			}
+
+			form := (*forms)[0]
+			if actual := form.Get(testCase.param); actual != testCase.expected {
+				t.Errorf("expected %s to be %q, got %q", testCase.param, testCase.expected, actual)
+			}
+
+			if actual := form.Get("api_key"); actual != "key" {
+				t.Errorf("expected api_key to be unchanged, got %q", actual)
+			}
+
+			if actual := form.Get("format"); actual != "json" {
+				t.Errorf("expected format to be unchanged, got %q", actual)
+			}
+		})
+	}
+}
+
+func TestEditMonitorResetsUnsetHttpSettings(t *testing.T) {
+	server, forms := newTestServer(t, `{"stat":"ok","monitor":{"id":1}}`)
+	client := newTestClient(t, server, "key")
+
+	_, err := client.EditMonitor(context.Background(), EditMonitorRequest{Id: "1", MonitorType: MonitorTypeHttp})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	form := (*forms)[0]
+	expected := map[string]string{
+		"http_username":        "",
+		"http_password":        "",
+		"http_auth_type":       "1",
+		"http_method":          "1",
+		"post_type":            "1",
+		"post_value":           "",
+		"post_content_type":    "0",
+		"custom_http_headers":  "{}",
+		"custom_http_statuses": "",
+	}
+	for param, value := range expected {
+		if !form.Has(param) {
+			t.Errorf("expected %s to be sent", param)
+			continue
+		}
+
+		if actual := form.Get(param); actual != value {
+			t.Errorf("expected %s to be reset to %q, got %q", param, value, actual)
+		}
+	}
+}
+
+func TestEditMonitorLeavesOutHttpSettingsOfOtherTypes(t *testing.T) {
+	for _, monitorType := range []int{3, 4, 5} {
+		t.Run(strconv.Itoa(monitorType), func(t *testing.T) {
+			server, forms := newTestServer(t, `{"stat":"ok","monitor":{"id":1}}`)
+			client := newTestClient(t, server, "key")
+
+			_, err := client.EditMonitor(context.Background(), EditMonitorRequest{Id: "1", MonitorType: monitorType})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			for _, param := range []string{"http_username", "http_password", "http_auth_type", "http_method", "post_type", "post_value", "post_content_type", "custom_http_headers", "custom_http_statuses"} {
+				if (*forms)[0].Has(param) {
+					t.Errorf("expected %s not to be sent", param)
+				}
+			}
+		})
+	}
+}
+
+func TestEditMonitorEncodesStatus(t *testing.T) {
+	paused, resumed := 0, 1
+	testCases := []struct {
+		name     string
+		expected []string
+		request  EditMonitorRequest
+	}{
+		{"pause", []string{"0"}, EditMonitorRequest{Id: "1", Status: &paused}},
+		{"resume", []string{"1"}, EditMonitorRequest{Id: "1", Status: &resumed}},
+		{"unchanged", nil, EditMonitorRequest{Id: "1"}},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			server, forms := newTestServer(t, `{"stat":"ok","monitor":{"id":1}}`)
+			client := newTestClient(t, server, "key")
+
+			_, err := client.EditMonitor(context.Background(), testCase.request)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if actual := (*forms)[0]["status"]; !reflect.DeepEqual(actual, testCase.expected) {
+				t.Errorf("expected status to be %v, got %v", testCase.expected, actual)
+			}
+		})
+	}
+}
+
+func TestEditMonitorEncodesBools(t *testing.T) {
+	testCases := []struct {
+		param    string
+		expected string
+		request  EditMonitorRequest
+	}{
+		{"ignore_ssl_errors", "1", EditMonitorRequest{Id: "1", IgnoreSSLErrors: true}},
+		{"ignore_ssl_errors", "0", EditMonitorRequest{Id: "1"}},
+		{"ssl_expiration_reminder", "1", EditMonitorRequest{Id: "1", SSLExpirationReminder: true}},
+		{"ssl_expiration_reminder", "0", EditMonitorRequest{Id: "1"}},
+		{"disable_domain_expire_notifications", "1", EditMonitorRequest{Id: "1", DisableDomainExpireNotifications: true}},
+		{"disable_domain_expire_notifications", "0", EditMonitorRequest{Id: "1"}},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.param+"="+testCase.expected, func(t *testing.T) {
+			server, forms := newTestServer(t, `{"stat":"ok","monitor":{"id":1}}`)
+			client := newTestClient(t, server, "key")
+
+			_, err := client.EditMonitor(context.Background(), testCase.request)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if actual := (*forms)[0].Get(testCase.param); actual != testCase.expected {
+				t.Errorf("expected %s to be %q, got %q", testCase.param, testCase.expected, actual)
+			}
+		})
+	}
+}
+
+func TestEditAlertContactEncodesParams(t *testing.T) {
+	server, forms := newTestServer(t, `{"stat":"ok","alert_contact":{"id":1}}`)
+	client := newTestClient(t, server, "key")
+
+	webhookUrl := "https://hooks.example.com/services?token=a+b&channel=#ops"
+	_, err := client.EditAlertContact(context.Background(), "1", webhookUrl, hostileValue)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	form := (*forms)[0]
+	if actual := form.Get("value"); actual != webhookUrl {
+		t.Errorf("expected value to be %q, got %q", webhookUrl, actual)
+	}
+
+	if actual := form.Get("friendly_name"); actual != hostileValue {
+		t.Errorf("expected friendly_name to be %q, got %q", hostileValue, actual)
+	}
+}
+
+func TestApiKeyIsEncodedAndRedacted(t *testing.T) {
+	apiKey := "u123&format=xml+secret"
+	server, forms := newTestServer(t, `{"stat":"ok","account":{}}`)
+	client := newTestClient(t, server, apiKey)
+
+	_, err := client.GetAccountDetails(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	form := (*forms)[0]
+	if actual := form.Get("api_key"); actual != apiKey {
+		t.Errorf("expected api_key to be %q, got %q", apiKey, actual)
+	}
+
+	if actual := form["format"]; len(actual) != 1 || actual[0] != "json" {
+		t.Errorf("expected a single json format param, got %q", actual)
+	}
+
+	for _, formatted := range []string{fmt.Sprint(client), fmt.Sprintf("%v", client), fmt.Sprintf("%#v", client)} {
+		if strings.Contains(formatted, "secret") {
+			t.Errorf("api key leaked when formatting client: %s", formatted)
+		}
+	}
+}
+
+func TestFailResponseReturnsAPIError(t *testing.T) {
+	testCases := []struct {
+		name              string
+		body              string
+		isNotFound        bool
+		isInvalidParam    bool
+		isUnauthorized    bool
+		expectedType      string
+		expectedParameter string
+	}{
+		{"not found", `{"stat":"fail","error":{"type":"not_found","parameter_name":"id","value":"123"}}`, true, false, false, "not_found", "id"},
+		{"invalid parameter", `{"stat":"fail","error":{"type":"invalid_parameter","parameter_name":"url","message":"url is invalid"}}`, false, true, false, "invalid_parameter", "url"},
+		{"invalid api key", `{"stat":"fail","error":{"type":"invalid_parameter","parameter_name":"api_key","message":"api_key is invalid."}}`, false, false, true, "invalid_parameter", "api_key"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			server, _ := newTestServer(t, testCase.body)
+			client := newTestClient(t, server, "key")
+
+			_, err := client.DeleteMonitor(context.Background(), 123)
+			apiError, ok := asAPIError(err)
+			if !ok {
+				t.Fatalf("expected an APIError, got %v", err)
+			}
+
+			if apiError.Type != testCase.expectedType || apiError.ParameterName != testCase.expectedParameter {
+				t.Errorf("unexpected error fields: %+v", apiError)
+			}
+
+			if IsNotFound(err) != testCase.isNotFound {
+				t.Errorf("expected IsNotFound to be %t", testCase.isNotFound)
+			}
+
+			if IsInvalidParameter(err) != testCase.isInvalidParam {
+				t.Errorf("expected IsInvalidParameter to be %t", testCase.isInvalidParam)
+			}
+
+			if IsUnauthorized(err) != testCase.isUnauthorized {
+				t.Errorf("expected IsUnauthorized to be %t", testCase.isUnauthorized)
+			}
+		})
+	}
+}
+
+func TestTooManyRequestsBlocksClient(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		requests++
+		writer.Header().Set("Retry-After", "30")
+		writer.WriteHeader(http.StatusTooManyRequests)
+	}))
+	t.Cleanup(server.Close)
+	client := newTestClient(t, server, "key")
+
+	for attempt := 0; attempt < 2; attempt++ {
+		_, err := client.GetAccountDetails(context.Background())
+		retryAfter, ok := RetryAfter(err)
+		if !ok || !IsRateLimited(err) {
+			t.Fatalf("expected a rate limited error, got %v", err)
+		}
+
+		if retryAfter <= 0 || retryAfter > 30*time.Second {
+			t.Errorf("expected to retry within 30s, got %s", retryAfter)
+		}
+	}
+
+	if requests != 1 {
+		t.Errorf("expected the client to stop calling the api once throttled, got %d requests", requests)
+	}
+}
+
+func TestRateLimiterThrottlesRequests(t *testing.T) {
+	server, forms := newTestServer(t, `{"stat":"ok","account":{}}`)
+	client, err := NewClient("key", WithBaseUrl(server.URL), WithRateLimit(RateLimit{RequestsPerMinute: 6}), WithMaxRateLimitWait(time.Second))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	_, err = client.GetAccountDetails(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, err = client.GetAccountDetails(context.Background())
+	if !IsRateLimited(err) {
+		t.Fatalf("expected the second request to be throttled, got %v", err)
+	}
+
+	if len(*forms) != 1 {
+		t.Errorf("expected 1 request to reach the api, got %d", len(*forms))
+	}
+}
+
+func TestUnexpectedResponsesReturnResponseError(t *testing.T) {
+	longPage := "<html>" + strings.Repeat("x", 1000) + "</html>"
+	testCases := []struct {
+		name        string
+		statusCode  int
+		contentType string
+		body        string
+		reason      string
+	}{
+		{"html error page", http.StatusBadGateway, "text/html", longPage, "response is not json"},
+		{"html with ok status", http.StatusOK, "text/html; charset=utf-8", "<html>maintenance</html>", "response is not json"},
+		{"empty body", http.StatusOK, "application/json", "", "empty response body"},
+		{"invalid json", http.StatusOK, "application/json", `{"stat":`, "response body is not valid json"},
+		{"json with error status", http.StatusInternalServerError, "application/json", `{"stat":"ok"}`, "unexpected http status"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+				writer.Header().Set("content-type", testCase.contentType)
+				writer.WriteHeader(testCase.statusCode)
+				fmt.Fprint(writer, testCase.body)
+			}))
+			t.Cleanup(server.Close)
+			client, err := NewClient("key", WithBaseUrl(server.URL), WithRetryPolicy(RetryPolicy{MaxAttempts: 1}))
+			if err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+
+			_, err = client.GetMonitors(context.Background(), []string{"1"})
+			var responseError *ResponseError
+			if !errors.As(err, &responseError) {
+				t.Fatalf("expected a ResponseError, got %v", err)
+			}
+
+			if responseError.Reason != testCase.reason || responseError.StatusCode != testCase.statusCode {
+				t.Errorf("unexpected error: %v", responseError)
+			}
+
+			if len(responseError.BodySample) > maxBodySampleLength+len("...") {
+				t.Errorf("expected the body sample to be truncated, got %d bytes", len(responseError.BodySample))
+			}
+		})
+	}
+}
+
+func TestFailWithErrorStatusReturnsAPIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writer.Header().Set("content-type", "application/json")
+		writer.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(writer, `{"stat":"fail","error":{"type":"unauthorized","message":"api key is invalid"}}`)
+	}))
+	t.Cleanup(server.Close)
+	client := newTestClient(t, server, "key")
+
+	_, err := client.GetAccountDetails(context.Background())
+	apiError, ok := asAPIError(err)
+	if !ok || apiError.StatusCode != http.StatusUnauthorized || !IsUnauthorized(err) {
+		t.Errorf("expected an unauthorized APIError, got %v", err)
+	}
+}
+
+func TestListAllMonitorsWalksEveryPage(t *testing.T) {
+	total := 120
+	var forms []url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if err := request.ParseForm(); err != nil {
+			t.Errorf("failed to parse form: %v", err)
+		}
+		forms = append(forms, request.PostForm)
+
+		offset, _ := strconv.Atoi(request.PostForm.Get("offset"))
+		limit, _ := strconv.Atoi(request.PostForm.Get("limit"))
+		response := GetMonitorResponse{Stat: "ok", Pagination: Pagination{Offset: offset, Limit: limit, Total: total}}
+		for id := offset; id < offset+limit && id < total; id++ {
+			response.Monitors = append(response.Monitors, MonitorDetails{Id: strconv.Itoa(id)})
+		}
+		writer.Header().Set("content-type", "application/json")
+		_ = json.NewEncoder(writer).Encode(response)
+	}))
+	t.Cleanup(server.Close)
+	client, err := NewClient("key", WithBaseUrl(server.URL), WithRateLimit(RateLimit{RequestsPerMinute: 6000}))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	var ids []string
+	err = client.ListAllMonitors(context.Background(), ListMonitorsOptions{
+		Search:               "example",
+		Types:                []int{1, 2},
+		Statuses:             []int{2, 9},
+		IncludeAlertContacts: true,
+	}, func(monitor MonitorDetails) error {
+		ids = append(ids, monitor.Id)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(ids) != total || ids[0] != "0" || ids[total-1] != strconv.Itoa(total-1) {
+		t.Errorf("expected %d monitors in order, got %d", total, len(ids))
+	}
+
+	if len(forms) != 3 {
+		t.Errorf("expected 3 pages, got %d", len(forms))
+	}
+
+	form := forms[0]
+	expected := map[string]string{"search": "example", "types": "1-2", "statuses": "2-9", "alert_contacts": "1", "limit": "50"}
+	for param, value := range expected {
+		if actual := form.Get(param); actual != value {
+			t.Errorf("expected %s to be %q, got %q", param, value, actual)
+		}
+	}
+}
+
+func TestGetMonitorsDecodesFieldsThatDontApply(t *testing.T) {
+	server, _ := newTestServer(t, `{"stat":"ok","pagination":{"offset":0,"limit":50,"total":2},"monitors":[`+
+		`{"id":"1","type":1,"sub_type":"","keyword_type":null,"keyword_case_type":"","port":""},`+
+		`{"id":"2","type":4,"sub_type":99,"keyword_type":"","keyword_case_type":null,"port":"8443"}]}`)
+	client := newTestClient(t, server, "key")
+
+	response, err := client.GetMonitorsPage(context.Background(), ListMonitorsOptions{}, 0, MaxPageSize)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(response.Monitors) != 2 {
+		t.Fatalf("expected 2 monitors, got %d", len(response.Monitors))
+	}
+
+	httpMonitor := response.Monitors[0]
+	if httpMonitor.SubType != 0 || httpMonitor.KeywordType != 0 || httpMonitor.KeywordCaseType != 0 || httpMonitor.Port != 0 {
+		t.Errorf("expected empty fields to decode as 0, got %+v", httpMonitor)
+	}
+
+	portMonitor := response.Monitors[1]
+	if portMonitor.SubType != 99 || portMonitor.Port != 8443 {
+		t.Errorf("expected sub type 99 and port 8443, got %+v", portMonitor)
+	}
+}
+
+func TestGetMonitorsDecodesLogs(t *testing.T) {
+	server, forms := newTestServer(t, `{"stat":"ok","pagination":{"offset":0,"limit":50,"total":1},"monitors":[`+
+		`{"id":"1","type":1,"status":9,"logs":[`+
+		`{"id":3,"type":1,"datetime":1700000600,"duration":120,"reason":{"code":"333333","detail":"Connection Timeout"}},`+
+		`{"id":2,"type":2,"datetime":1700000000,"duration":600,"reason":{"code":200,"detail":"OK"}}]}]}`)
+	client := newTestClient(t, server, "key")
+
+	response, err := client.GetMonitorsPage(context.Background(), ListMonitorsOptions{IncludeLogs: true, LogsLimit: 2}, 0, MaxPageSize)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	form := (*forms)[0]
+	if form.Get("logs") != "1" || form.Get("logs_limit") != "2" {
+		t.Errorf("expected logs=1 and logs_limit=2, got %v", form)
+	}
+
+	logs := response.Monitors[0].Logs
+	if len(logs) != 2 {
+		t.Fatalf("expected 2 logs, got %d", len(logs))
+	}
+
+	if logs[0].Type != MonitorLogDown || logs[0].Datetime != 1700000600 || logs[0].Duration != 120 || logs[0].Reason.Detail != "Connection Timeout" {
+		t.Errorf("unexpected down log %+v", logs[0])
+	}
+}
+
+func TestGetMonitorsPairsUptimeRatiosWithPeriods(t *testing.T) {
+	server, forms := newTestServer(t, `{"stat":"ok","pagination":{"offset":0,"limit":50,"total":1},"monitors":[`+
+		`{"id":"1","type":1,"custom_uptime_ratio":"100.000-99.950-99.985","all_time_uptime_ratio":"99.982","average_response_time":"245.333"}]}`)
+	client := newTestClient(t, server, "key")
+
+	response, err := client.GetMonitorsPage(context.Background(), ListMonitorsOptions{
+		CustomUptimeRatios:        []int{1, 7, 30},
+		IncludeAllTimeUptimeRatio: true,
+		IncludeResponseTimes:      true,
+		ResponseTimesLimit:        1,
+	}, 0, MaxPageSize)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	form := (*forms)[0]
+	expected := map[string]string{"custom_uptime_ratios": "1-7-30", "all_time_uptime_ratio": "1", "response_times": "1", "response_times_limit": "1"}
+	for param, value := range expected {
+		if actual := form.Get(param); actual != value {
+			t.Errorf("expected %s to be %q, got %q", param, value, actual)
+		}
+	}
+
+	monitor := response.Monitors[0]
+	if monitor.UptimeRatios[1] != "100.000" || monitor.UptimeRatios[7] != "99.950" || monitor.UptimeRatios[30] != "99.985" {
+		t.Errorf("unexpected uptime ratios %v", monitor.UptimeRatios)
+	}
+
+	if monitor.AllTimeUptimeRatio != "99.982" || monitor.AverageResponseTime != "245.333" {
+		t.Errorf("unexpected all time uptime ratio %q or average response time %q", monitor.AllTimeUptimeRatio, monitor.AverageResponseTime)
+	}
+}
+
+func TestGetMonitorsDecodesSSL(t *testing.T) {
+	server, _ := newTestServer(t, `{"stat":"ok","pagination":{"offset":0,"limit":50,"total":2},"monitors":[`+
+		`{"id":"1","type":1,"ssl":{"brand":"Let's Encrypt","product":"R3","expires":1700000000}},`+
+		`{"id":"2","type":1,"ssl":""}]}`)
+	client := newTestClient(t, server, "key")
+
+	response, err := client.GetMonitorsPage(context.Background(), ListMonitorsOptions{IncludeSSL: true}, 0, MaxPageSize)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if ssl := response.Monitors[0].SSL; ssl.Brand != "Let's Encrypt" || ssl.Expires != 1700000000 {
+		t.Errorf("unexpected ssl %+v", ssl)
+	}
+
+	if ssl := response.Monitors[1].SSL; ssl != (MonitorSSL{}) {
+		t.Errorf("expected no ssl, got %+v", ssl)
+	}
+}
+
+func TestGetMonitorsDecodesAlertContacts(t *testing.T) {
+	server, _ := newTestServer(t, `{"stat":"ok","pagination":{"offset":0,"limit":50,"total":1},"monitors":[`+
+		`{"id":"1","type":1,"alert_contacts":[{"id":"2","value":"ops@example.com","type":2,"threshold":5,"recurrence":"10"},{"id":3,"threshold":0,"recurrence":0}]}]}`)
+	client := newTestClient(t, server, "key")
+
+	response, err := client.GetMonitorsPage(context.Background(), ListMonitorsOptions{IncludeAlertContacts: true}, 0, MaxPageSize)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var encoded []string
+	for _, alertContact := range response.Monitors[0].AlertContacts {
+		encoded = append(encoded, alertContact.String())
+	}
+
+	if expected := []string{"2_5_10", "3_0_0"}; !reflect.DeepEqual(encoded, expected) {
+		t.Errorf("expected alert contacts %v, got %v", expected, encoded)
+	}
+}
+
+func TestEditMonitorDetachesAlertContacts(t *testing.T) {
+	server, forms := newTestServer(t, `{"stat":"ok","monitor":{"id":1}}`)
+	client := newTestClient(t, server, "key")
+
+	_, err := client.EditMonitor(context.Background(), EditMonitorRequest{Id: "1"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	form := (*forms)[0]
+	if !form.Has("alert_contacts") || form.Get("alert_contacts") != "" {
+		t.Errorf("expected alert_contacts to be sent empty, got %q", form.Get("alert_contacts"))
+	}
+}
+
+func TestNewMWindowEncodesParams(t *testing.T) {
+	server, forms := newTestServer(t, `{"stat":"ok","mwindow":{"id":7,"status":1}}`)
+	client := newTestClient(t, server, "key")
+
+	response, err := client.NewMWindow(context.Background(), NewMWindowRequest{
+		FriendlyName: hostileValue,
+		Type:         MWindowTypeWeekly,
+		Value:        "2-4",
+		StartTime:    "22:00",
+		Duration:     60,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if response.MWindow.Id != 7 {
+		t.Errorf("expected id 7, got %d", response.MWindow.Id)
+	}
+
+	form := (*forms)[0]
+	expected := map[string]string{"friendly_name": hostileValue, "type": "3", "value": "2-4", "start_time": "22:00", "duration": "60"}
+	for param, value := range expected {
+		if actual := form.Get(param); actual != value {
+			t.Errorf("expected %s to be %q, got %q", param, value, actual)
+		}
+	}
+}
+
+func TestGetMWindowsDecodesStartTimes(t *testing.T) {
+	server, _ := newTestServer(t, `{"stat":"ok","pagination":{"offset":0,"limit":50,"total":2},"mwindows":[`+
+		`{"id":1,"type":1,"friendly_name":"once","value":"","start_time":1700000000,"duration":30,"status":1},`+
+		`{"id":"2","type":3,"friendly_name":"weekly","value":"2-4","start_time":"22:00","duration":60,"status":0}]}`)
+	client := newTestClient(t, server, "key")
+
+	response, err := client.GetMWindowsPage(context.Background(), ListMWindowsOptions{}, 0, MaxPageSize)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(response.MWindows) != 2 {
+		t.Fatalf("expected 2 maintenance windows, got %d", len(response.MWindows))
+	}
+
+	if once := response.MWindows[0]; once.Id != 1 || once.StartTime != "1700000000" {
+		t.Errorf("unexpected once window %+v", once)
+	}
+
+	if weekly := response.MWindows[1]; weekly.Id != 2 || weekly.StartTime != "22:00" || weekly.Value != "2-4" {
+		t.Errorf("unexpected weekly window %+v", weekly)
+	}
+}
+
+func TestNewPSPEncodesParams(t *testing.T) {
+	server, forms := newTestServer(t, `{"stat":"ok","psp":{"id":9}}`)
+	client := newTestClient(t, server, "key")
+
+	response, err := client.NewPSP(context.Background(), NewPSPRequest{
+		FriendlyName: hostileValue,
+		Monitors:     "1-2",
+		Password:     hostileValue,
+		Sort:         PSPSortStatusDownFirst,
+		HideUrlLinks: true,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if response.PSP.Id != 9 {
+		t.Errorf("expected id 9, got %d", response.PSP.Id)
+	}
+
+	form := (*forms)[0]
+	expected := map[string]string{"type": "1", "friendly_name": hostileValue, "monitors": "1-2", "password": hostileValue, "sort": "4", "hide_url_links": "1"}
+	for param, value := range expected {
+		if actual := form.Get(param); actual != value {
+			t.Errorf("expected %s to be %q, got %q", param, value, actual)
+		}
+	}
+
+	if form.Has("custom_domain") {
+		t.Errorf("expected custom_domain to be omitted, got %q", form.Get("custom_domain"))
+	}
+}
+
+func TestEditPSPClearsSettings(t *testing.T) {
+	server, forms := newTestServer(t, `{"stat":"ok","psp":{"id":9}}`)
+	client := newTestClient(t, server, "key")
+
+	_, err := client.EditPSP(context.Background(), EditPSPRequest{Id: "9", FriendlyName: "page", Monitors: "1"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	form := (*forms)[0]
+	for _, param := range []string{"custom_domain", "password"} {
+		if !form.Has(param) || form.Get(param) != "" {
+			t.Errorf("expected %s to be sent empty, got %q", param, form.Get(param))
+		}
+	}
+
+	if actual := form.Get("hide_url_links"); actual != "0" {
+		t.Errorf("expected hide_url_links to be %q, got %q", "0", actual)
+	}
+}
+
+func TestGetPSPsDecodesMonitors(t *testing.T) {
+	server, _ := newTestServer(t, `{"stat":"ok","pagination":{"offset":0,"limit":50,"total":2},"psps":[`+
+		`{"id":1,"friendly_name":"all","monitors":0,"sort":1,"status":1,"standard_url":"https://stats.uptimerobot.com/a","custom_url":""},`+
+		`{"id":"2","friendly_name":"some","monitors":[3,"4"],"sort":"4","status":1,"standard_url":"https://stats.uptimerobot.com/b","custom_url":"https://status.example.com"}]}`)
+	client := newTestClient(t, server, "key")
+
+	response, err := client.GetPSPsPage(context.Background(), ListPSPsOptions{}, 0, MaxPageSize)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(response.PSPs) != 2 {
+		t.Fatalf("expected 2 status pages, got %d", len(response.PSPs))
+	}
+
+	if all := response.PSPs[0]; all.Id != 1 || all.Monitors != nil {
+		t.Errorf("unexpected status page %+v", all)
+	}
+
+	if some := response.PSPs[1]; some.Id != 2 || some.Sort != PSPSortStatusDownFirst || !reflect.DeepEqual(some.Monitors, PSPMonitors{3, 4}) || some.CustomUrl != "https://status.example.com" {
+		t.Errorf("unexpected status page %+v", some)
+	}
+}
+
+func TestHttpClientTimeout(t *testing.T) {
+	injected := &http.Client{Timeout: 2 * time.Minute}
+	testCases := []struct {
+		name     string
+		options  []ClientOption
+		expected time.Duration
+	}{
+		{"default", nil, DefaultTimeout},
+		{"given", []ClientOption{WithTimeout(time.Second)}, time.Second},
+		{"injected client keeps its own", []ClientOption{WithHttpClient(injected)}, 2 * time.Minute},
+		{"injected client with a timeout given", []ClientOption{WithHttpClient(injected), WithTimeout(time.Second)}, time.Second},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			client, err := NewClient("key", testCase.options...)
+			if err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+
+			if client.httpClient.Timeout != testCase.expected {
+				t.Errorf("expected a timeout of %s, got %s", testCase.expected, client.httpClient.Timeout)
+			}
+		})
+	}
+
+	if injected.Timeout != 2*time.Minute {
+		t.Errorf("expected the injected client to be left alone, its timeout is %s", injected.Timeout)
+	}
+}
@@ -0,0 +1,59 @@
+package uptimerobot
+
+import "sync"
+
+// ClientPool hands out one Client per UptimeRobot account so that every
+// controller talking to the same account shares a single client
+type ClientPool struct {
+	mutex         sync.Mutex
+	defaultClient *Client
+	clients       map[string]*Client
+	options       []ClientOption
+}
+
+// NewClientPool creates a pool whose account clients are built with the given options
+func NewClientPool(defaultClient *Client, options ...ClientOption) *ClientPool {
+	return &ClientPool{
+		defaultClient: defaultClient,
+		clients:       map[string]*Client{},
+		options:       options,
+	}
+}
+
+// Default returns the client used for resources that don't reference an account
+func (pool *ClientPool) Default() *Client {
+	return pool.defaultClient
+}
+
+// ForAccount returns the client for the given account, creating it on first
+// use and swapping its api key when the key has changed
+func (pool *ClientPool) ForAccount(account string, apiKey string) (*Client, error) {
+	pool.mutex.Lock()
+	defer pool.mutex.Unlock()
+
+	client, ok := pool.clients[account]
+	if !ok {
+		client, err := NewClient(apiKey, pool.options...)
+		if err != nil {
+			return nil, err
+		}
+
+		pool.clients[account] = client
+		return client, nil
+	}
+
+	if client.getApiKey() != apiKey {
+		client.SetApiKey(apiKey)
+	}
+
+	return client, nil
+}
+
+// Remove forgets the client for an account that no longer exists and returns it, nil when there was none
+func (pool *ClientPool) Remove(account string) *Client {
+	pool.mutex.Lock()
+	defer pool.mutex.Unlock()
+	client := pool.clients[account]
+	delete(pool.clients, account)
+	return client
+}
@@ -0,0 +1,190 @@
+package uptimerobot
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+const (
+	ErrorTypeNotFound         = "not_found"
+	ErrorTypeInvalidParameter = "invalid_parameter"
+	ErrorTypeMissingParameter = "missing_parameter"
+	ErrorTypeUnauthorized     = "unauthorized"
+	ErrorTypeRateLimited      = "rate_limit"
+)
+
+// APIError is the error returned when the UptimeRobot api responds with stat "fail"
+type APIError struct {
+	Type          string `json:"type"`
+	ParameterName string `json:"parameter_name"`
+	Message       string `json:"message"`
+	Value         string `json:"value"`
+	StatusCode    int    `json:"-"`
+}
+
+func (err *APIError) Error() string {
+	errorType := err.Type
+	if errorType == "" {
+		errorType = "unknown"
+	}
+
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "uptimerobot api error: %s", errorType)
+	if err.ParameterName != "" {
+		fmt.Fprintf(&builder, " (parameter %s)", err.ParameterName)
+	}
+	if err.Message != "" {
+		fmt.Fprintf(&builder, ": %s", err.Message)
+	}
+	if err.StatusCode != 0 && err.StatusCode != http.StatusOK {
+		fmt.Fprintf(&builder, " [http %d]", err.StatusCode)
+	}
+
+	return builder.String()
+}
+
+type failResponse struct {
+	Stat  string   `json:"stat"`
+	Error APIError `json:"error"`
+}
+
+const maxBodySampleLength = 256
+
+// ResponseError is returned when the api answers with something other than the JSON it
+// documents, like an unexpected http status or an html error page from a proxy
+type ResponseError struct {
+	Reason      string
+	StatusCode  int
+	ContentType string
+	// BodySample is the start of the response body, truncated to keep logs readable
+	BodySample string
+}
+
+func (err *ResponseError) Error() string {
+	return fmt.Sprintf("unexpected uptimerobot api response: %s (http %d, content-type %q): %q",
+		err.Reason, err.StatusCode, err.ContentType, err.BodySample)
+}
+
+func newResponseError(reason string, response apiResponse) *ResponseError {
+	bodySample := string(response.body)
+	if len(bodySample) > maxBodySampleLength {
+		bodySample = bodySample[:maxBodySampleLength] + "..."
+	}
+
+	return &ResponseError{
+		Reason:      reason,
+		StatusCode:  response.statusCode,
+		ContentType: response.header.Get("Content-Type"),
+		BodySample:  bodySample,
+	}
+}
+
+// isJsonContentType accepts a missing content type as well as application/json and its +json variants
+func isJsonContentType(contentType string) bool {
+	if contentType == "" {
+		return true
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+// checkResponse classifies a raw api response before it is decoded, returning an APIError
+// for documented failures and a ResponseError for anything else that isn't a JSON success
+func checkResponse(response apiResponse) error {
+	if len(response.body) == 0 {
+		return newResponseError("empty response body", response)
+	}
+
+	if !isJsonContentType(response.header.Get("Content-Type")) {
+		return newResponseError("response is not json", response)
+	}
+
+	if !json.Valid(response.body) {
+		return newResponseError("response body is not valid json", response)
+	}
+
+	failure := failResponse{}
+	err := json.Unmarshal(response.body, &failure)
+	if err != nil {
+		return newResponseError("response body is not a json object", response)
+	}
+
+	if failure.Stat == "fail" {
+		failure.Error.StatusCode = response.statusCode
+		return &failure.Error
+	}
+
+	if response.statusCode < http.StatusOK || response.statusCode >= http.StatusMultipleChoices {
+		return newResponseError("unexpected http status", response)
+	}
+
+	return nil
+}
+
+func asAPIError(err error) (*APIError, bool) {
+	var apiError *APIError
+	if errors.As(err, &apiError) {
+		return apiError, true
+	}
+
+	return nil, false
+}
+
+func statusCodeOf(err error) int {
+	if apiError, ok := asAPIError(err); ok {
+		return apiError.StatusCode
+	}
+
+	var responseError *ResponseError
+	if errors.As(err, &responseError) {
+		return responseError.StatusCode
+	}
+
+	return 0
+}
+
+// IsNotFound is true when the api reports that the requested object doesn't exist
+func IsNotFound(err error) bool {
+	apiError, ok := asAPIError(err)
+	return ok && (apiError.Type == ErrorTypeNotFound || apiError.StatusCode == http.StatusNotFound)
+}
+
+// IsRateLimited is true when the api rejected the request for exceeding the account's rate limit
+func IsRateLimited(err error) bool {
+	if _, ok := RetryAfter(err); ok {
+		return true
+	}
+
+	apiError, ok := asAPIError(err)
+	return (ok && apiError.Type == ErrorTypeRateLimited) || statusCodeOf(err) == http.StatusTooManyRequests
+}
+
+// IsInvalidParameter is true when the api rejected a missing or malformed request parameter
+func IsInvalidParameter(err error) bool {
+	apiError, ok := asAPIError(err)
+	if !ok || apiError.ParameterName == "api_key" {
+		return false
+	}
+
+	return apiError.Type == ErrorTypeInvalidParameter || apiError.Type == ErrorTypeMissingParameter
+}
+
+// IsUnauthorized is true when the api rejected the api key used for the request
+func IsUnauthorized(err error) bool {
+	statusCode := statusCodeOf(err)
+	if statusCode == http.StatusUnauthorized || statusCode == http.StatusForbidden {
+		return true
+	}
+
+	apiError, ok := asAPIError(err)
+	return ok && (apiError.Type == ErrorTypeUnauthorized || apiError.ParameterName == "api_key")
+}
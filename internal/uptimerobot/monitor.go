@@ -1,6 +1,12 @@
 package uptimerobot
 
-import "context"
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
 
 type NewMonitorResponse struct {
 	Stat    string `json:"stat"`
@@ -37,6 +43,7 @@ type NewMonitorRequest struct {
 	CustomHttpHeaders                string   `json:"custom_http_headers"`
 	CustomHttpStatuses               string   `json:"custom_http_statuses"`
 	IgnoreSSLErrors                  bool     `json:"ignore_ssl_errors"`
+	SSLExpirationReminder            bool     `json:"ssl_expiration_reminder"`
 	DisableDomainExpireNotifications bool     `json:"disable_domain_expire_notifications"`
 }
 
@@ -60,17 +67,21 @@ type MonitorDeleter interface {
 }
 
 type EditMonitorRequest struct {
-	Id                               string   `json:"id"`
-	FriendlyName                     string   `json:"friendly_name"`
-	Url                              string   `json:"url"`
-	SubType                          int      `json:"sub_type"`
-	Port                             int      `json:"port"`
-	KeywordType                      int      `json:"keyword_type"`
-	KeywordCaseType                  int      `json:"keyword_case_type"`
-	KeywordValue                     string   `json:"keyword_value"`
-	Interval                         int      `json:"interval"`
-	Timeout                          int      `json:"timeout"`
-	Status                           int      `json:"status"`
+	Id string `json:"id"`
+	// MonitorType is the type the monitor was created with, it can't be edited and only decides which
+	// settings are sent
+	MonitorType     int    `json:"-"`
+	FriendlyName    string `json:"friendly_name"`
+	Url             string `json:"url"`
+	SubType         int    `json:"sub_type"`
+	Port            int    `json:"port"`
+	KeywordType     int    `json:"keyword_type"`
+	KeywordCaseType int    `json:"keyword_case_type"`
+	KeywordValue    string `json:"keyword_value"`
+	Interval        int    `json:"interval"`
+	Timeout         int    `json:"timeout"`
+	// Status pauses the monitor when 0 and resumes it when 1, it isn't sent when nil
+	Status                           *int     `json:"status"`
 	HttpUsername                     string   `json:"http_username"`
 	HttpPassword                     string   `json:"http_password"`
 	HttpAuthType                     int      `json:"http_auth_type"`
@@ -83,6 +94,7 @@ type EditMonitorRequest struct {
 	CustomHttpHeaders                string   `json:"custom_http_headers"`
 	CustomHttpStatuses               string   `json:"custom_http_statuses"`
 	IgnoreSSLErrors                  bool     `json:"ignore_ssl_errors"`
+	SSLExpirationReminder            bool     `json:"ssl_expiration_reminder"`
 	DisableDomainExpireNotifications bool     `json:"disable_domain_expire_notifications"`
 }
 
@@ -101,36 +113,159 @@ type MonitorEditor interface {
 	EditMonitor(ctx context.Context, request EditMonitorRequest) (EditMonitorResponse, error)
 }
 
-type GetMonitorResponse struct {
-	Stat       string `json:"stat"`
-	Pagination struct {
-		Offset int `json:"offset"`
-		Limit  int `json:"limit"`
-		Total  int `json:"total"`
-	} `json:"pagination"`
-	Monitors []struct {
-		Id              string `json:"id"`
-		FriendlyName    string `json:"friendly_name"`
-		Url             string `json:"url"`
-		MonitorType     int    `json:"type"`
-		SubType         int    `json:"sub_type"`
-		KeywordType     int    `json:"keyword_type"`
-		KeywordCaseType int    `json:"keyword_case_type"`
-		KeywordValue    string `json:"keyword_value"`
-		HttpUsername    string `json:"http_username"`
-		HttpPassword    string `json:"http_password"`
-		Port            int    `json:"port"`
-		Interval        int    `json:"interval"`
-		Status          int    `json:"status"`
-		CreateDatetime  int    `json:"create_datetime"`
-		MonitorGroup    int    `json:"monitor_group"`
-		IsGroupMain     int    `json:"is_group_main"`
-		Logs            struct {
-			Type     int `json:"type"`
-			Datetime int `json:"datetime"`
-			Duration int `json:"duration"`
-		} `json:"logs"`
+type Pagination struct {
+	Offset int `json:"offset"`
+	Limit  int `json:"limit"`
+	Total  int `json:"total"`
+}
+
+// OptionalInt is an int field that getMonitors returns as "" or null for monitor types it
+// doesn't apply to, e.g. the port of an http monitor. Those decode as 0.
+type OptionalInt int
+
+func (value *OptionalInt) UnmarshalJSON(data []byte) error {
+	data = bytes.Trim(data, `"`)
+	if len(data) == 0 || string(data) == "null" {
+		*value = 0
+		return nil
+	}
+
+	parsed, err := strconv.Atoi(string(data))
+	if err != nil {
+		return fmt.Errorf("cannot decode %s as an integer: %w", data, err)
+	}
+
+	*value = OptionalInt(parsed)
+	return nil
+}
+
+// OptionalNumber is a decimal field such as an uptime ratio, which getMonitors returns as a string
+// but may return as a number or null. It is kept as text so no precision is lost.
+type OptionalNumber string
+
+func (value *OptionalNumber) UnmarshalJSON(data []byte) error {
+	data = bytes.Trim(data, `"`)
+	if string(data) == "null" {
+		*value = ""
+		return nil
+	}
+
+	*value = OptionalNumber(data)
+	return nil
+}
+
+const (
+	MonitorTypeHttp    = 1
+	MonitorTypeKeyword = 2
+
+	MonitorStatusPaused     = 0
+	MonitorStatusNotChecked = 1
+	MonitorStatusUp         = 2
+	MonitorStatusSeemsDown  = 8
+	MonitorStatusDown       = 9
+
+	HttpMethodHead      = 1
+	HttpMethodGet       = 2
+	HttpAuthTypeBasic   = 1
+	PostTypeKeyValue    = 1
+	PostContentTypeHtml = 0
+
+	MonitorLogDown    = 1
+	MonitorLogUp      = 2
+	MonitorLogStarted = 98
+	MonitorLogPaused  = 99
+)
+
+type MonitorLogReason struct {
+	Detail string `json:"detail"`
+}
+
+// MonitorLog is a change of a monitor's state
+type MonitorLog struct {
+	Type int `json:"type"`
+	// Datetime is when the state changed, in unix seconds
+	Datetime int `json:"datetime"`
+	// Duration is how long the state lasted, in seconds
+	Duration int              `json:"duration"`
+	Reason   MonitorLogReason `json:"reason"`
+}
+
+// MonitorSSL is the certificate of an https monitor, getMonitors only returns it when requested
+type MonitorSSL struct {
+	Brand   string `json:"brand"`
+	Product string `json:"product"`
+	// Expires is when the certificate expires, in unix seconds
+	Expires OptionalInt `json:"expires"`
+}
+
+// UnmarshalJSON decodes the "" or [] returned for monitors without a certificate as an empty MonitorSSL
+func (ssl *MonitorSSL) UnmarshalJSON(data []byte) error {
+	if !bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+		*ssl = MonitorSSL{}
+		return nil
 	}
+
+	type plainMonitorSSL MonitorSSL
+	return json.Unmarshal(data, (*plainMonitorSSL)(ssl))
+}
+
+// MonitorAlertContact is an alert contact of a monitor and when it is notified
+type MonitorAlertContact struct {
+	Id OptionalInt `json:"id"`
+	// Threshold is how many minutes the monitor is down before the contact is notified
+	Threshold OptionalInt `json:"threshold"`
+	// Recurrence is how often, in minutes, the contact is notified again while the monitor stays down,
+	// 0 notifies it once
+	Recurrence OptionalInt `json:"recurrence"`
+}
+
+// String encodes the contact as the id_threshold_recurrence triple the AlertContacts of monitor requests take
+func (contact MonitorAlertContact) String() string {
+	return fmt.Sprintf("%d_%d_%d", contact.Id, contact.Threshold, contact.Recurrence)
+}
+
+// MonitorMWindow is a maintenance window attached to a monitor
+type MonitorMWindow struct {
+	Id OptionalInt `json:"id"`
+}
+
+type MonitorDetails struct {
+	Id              string      `json:"id"`
+	FriendlyName    string      `json:"friendly_name"`
+	Url             string      `json:"url"`
+	MonitorType     int         `json:"type"`
+	SubType         OptionalInt `json:"sub_type"`
+	KeywordType     OptionalInt `json:"keyword_type"`
+	KeywordCaseType OptionalInt `json:"keyword_case_type"`
+	KeywordValue    string      `json:"keyword_value"`
+	HttpUsername    string      `json:"http_username"`
+	HttpPassword    string      `json:"http_password"`
+	Port            OptionalInt `json:"port"`
+	Interval        int         `json:"interval"`
+	Timeout         OptionalInt `json:"timeout"`
+	Status          int         `json:"status"`
+	CreateDatetime  int         `json:"create_datetime"`
+	MonitorGroup    int         `json:"monitor_group"`
+	IsGroupMain     int         `json:"is_group_main"`
+	// Logs are only returned when requested, newest first
+	Logs []MonitorLog `json:"logs"`
+	// CustomUptimeRatio holds the ratios of the requested periods joined with "-", see UptimeRatios
+	CustomUptimeRatio   OptionalNumber `json:"custom_uptime_ratio"`
+	AllTimeUptimeRatio  OptionalNumber `json:"all_time_uptime_ratio"`
+	AverageResponseTime OptionalNumber `json:"average_response_time"`
+	SSL                 MonitorSSL     `json:"ssl"`
+	// AlertContacts are only returned when requested
+	AlertContacts []MonitorAlertContact `json:"alert_contacts"`
+	// MaintenanceWindows are only returned when requested
+	MaintenanceWindows []MonitorMWindow `json:"mwindows"`
+	// UptimeRatios are the percentages of CustomUptimeRatio keyed by their period in days
+	UptimeRatios map[int]string `json:"-"`
+}
+
+type GetMonitorResponse struct {
+	Stat       string           `json:"stat"`
+	Pagination Pagination       `json:"pagination"`
+	Monitors   []MonitorDetails `json:"monitors"`
 }
 
 func (c GetMonitorResponse) GetStat() string {
@@ -140,3 +275,30 @@ func (c GetMonitorResponse) GetStat() string {
 type MonitorGetter interface {
 	GetMonitors(ctx context.Context, monitorIds []string) (GetMonitorResponse, error)
 }
+
+// ListMonitorsOptions filters and extends the monitors returned by getMonitors
+type ListMonitorsOptions struct {
+	MonitorIds  []string
+	Types       []int
+	Statuses    []int
+	Search      string
+	IncludeLogs bool
+	// LogsLimit caps the logs returned per monitor when IncludeLogs is set
+	LogsLimit                 int
+	IncludeAlertContacts      bool
+	IncludeMaintenanceWindows bool
+	IncludeSSL                bool
+	IncludeCustomHttpHeaders  bool
+	IncludeCustomHttpStatuses bool
+	IncludeAllTimeUptimeRatio bool
+	IncludeResponseTimes      bool
+	// ResponseTimesLimit caps the response times returned per monitor when IncludeResponseTimes is set
+	ResponseTimesLimit int
+	// CustomUptimeRatios are the periods, in days, to report uptime ratios for
+	CustomUptimeRatios []int
+}
+
+type MonitorLister interface {
+	GetMonitorsPage(ctx context.Context, options ListMonitorsOptions, offset int, limit int) (GetMonitorResponse, error)
+	ListAllMonitors(ctx context.Context, options ListMonitorsOptions, visit func(monitor MonitorDetails) error) error
+}
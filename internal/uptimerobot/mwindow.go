@@ -0,0 +1,122 @@
+package uptimerobot
+
+import (
+	"bytes"
+	"context"
+)
+
+const (
+	MWindowTypeOnce    = 1
+	MWindowTypeDaily   = 2
+	MWindowTypeWeekly  = 3
+	MWindowTypeMonthly = 4
+)
+
+// MWindowStartTime is when a maintenance window starts, getMWindows returns a unix timestamp for
+// once windows and HH:mm for the others, either of which may be quoted
+type MWindowStartTime string
+
+func (value *MWindowStartTime) UnmarshalJSON(data []byte) error {
+	data = bytes.Trim(data, `"`)
+	if string(data) == "null" {
+		*value = ""
+		return nil
+	}
+
+	*value = MWindowStartTime(data)
+	return nil
+}
+
+type NewMWindowRequest struct {
+	FriendlyName string `json:"friendly_name"`
+	Type         int    `json:"type"`
+	// Value lists the days, joined with "-", weekly and monthly windows start on
+	Value     string `json:"value"`
+	StartTime string `json:"start_time"`
+	// Duration is in minutes
+	Duration int `json:"duration"`
+}
+
+type NewMWindowResponse struct {
+	Stat    string `json:"stat"`
+	MWindow struct {
+		Id     int `json:"id"`
+		Status int `json:"status"`
+	} `json:"mwindow"`
+}
+
+func (c NewMWindowResponse) GetStat() string {
+	return c.Stat
+}
+
+type MWindowCreator interface {
+	NewMWindow(ctx context.Context, request NewMWindowRequest) (NewMWindowResponse, error)
+}
+
+type EditMWindowRequest struct {
+	Id           string `json:"id"`
+	FriendlyName string `json:"friendly_name"`
+	Value        string `json:"value"`
+	StartTime    string `json:"start_time"`
+	Duration     int    `json:"duration"`
+}
+
+type EditMWindowResponse struct {
+	Stat    string `json:"stat"`
+	MWindow struct {
+		Id int `json:"id"`
+	} `json:"mwindow"`
+}
+
+func (c EditMWindowResponse) GetStat() string {
+	return c.Stat
+}
+
+type MWindowEditor interface {
+	EditMWindow(ctx context.Context, request EditMWindowRequest) (EditMWindowResponse, error)
+}
+
+type DeleteMWindowResponse struct {
+	Stat    string `json:"stat"`
+	MWindow struct {
+		Id OptionalInt `json:"id"`
+	} `json:"mwindow"`
+}
+
+func (c DeleteMWindowResponse) GetStat() string {
+	return c.Stat
+}
+
+type MWindowDeleter interface {
+	DeleteMWindow(ctx context.Context, id string) (DeleteMWindowResponse, error)
+}
+
+type MWindowDetails struct {
+	Id           OptionalInt      `json:"id"`
+	FriendlyName string           `json:"friendly_name"`
+	Type         int              `json:"type"`
+	Value        string           `json:"value"`
+	StartTime    MWindowStartTime `json:"start_time"`
+	Duration     int              `json:"duration"`
+	Status       int              `json:"status"`
+}
+
+type GetMWindowsResponse struct {
+	Stat       string           `json:"stat"`
+	Pagination Pagination       `json:"pagination"`
+	MWindows   []MWindowDetails `json:"mwindows"`
+}
+
+func (c GetMWindowsResponse) GetStat() string {
+	return c.Stat
+}
+
+// ListMWindowsOptions filters the maintenance windows returned by getMWindows
+type ListMWindowsOptions struct {
+	MWindowIds []string
+}
+
+type MWindowLister interface {
+	GetMWindowsPage(ctx context.Context, options ListMWindowsOptions, offset int, limit int) (GetMWindowsResponse, error)
+	ListAllMWindows(ctx context.Context, options ListMWindowsOptions, visit func(mwindow MWindowDetails) error) error
+}
@@ -0,0 +1,173 @@
+package uptimerobot
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultBaseUrl   = "https://api.uptimerobot.com/v2/"
+	DefaultUserAgent = "uptime-robot-operator"
+	DefaultTimeout   = 30 * time.Second
+)
+
+type clientOptions struct {
+	baseUrl          string
+	httpClient       *http.Client
+	userAgent        string
+	timeout          time.Duration
+	proxyUrl         *url.URL
+	caBundle         []byte
+	rateLimit        RateLimit
+	maxRateLimitWait time.Duration
+	retryPolicy      RetryPolicy
+}
+
+// ClientOption customises how a Client talks to the UptimeRobot api
+type ClientOption func(options *clientOptions)
+
+// WithBaseUrl points the client at a different api endpoint, e.g. a local stand-in server
+func WithBaseUrl(baseUrl string) ClientOption {
+	return func(options *clientOptions) {
+		options.baseUrl = baseUrl
+	}
+}
+
+// WithHttpClient makes the client send requests through the given http.Client, keeping its timeout
+// unless WithTimeout is given too. It can't be combined with WithProxy or WithCABundle, configure
+// its transport instead.
+func WithHttpClient(httpClient *http.Client) ClientOption {
+	return func(options *clientOptions) {
+		options.httpClient = httpClient
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with every request
+func WithUserAgent(userAgent string) ClientOption {
+	return func(options *clientOptions) {
+		options.userAgent = userAgent
+	}
+}
+
+// WithTimeout limits how long a single api request may take, DefaultTimeout unless given
+func WithTimeout(timeout time.Duration) ClientOption {
+	return func(options *clientOptions) {
+		options.timeout = timeout
+	}
+}
+
+// WithProxy sends every request through the given proxy instead of the one from the environment
+func WithProxy(proxyUrl *url.URL) ClientOption {
+	return func(options *clientOptions) {
+		options.proxyUrl = proxyUrl
+	}
+}
+
+// WithCABundle trusts the PEM encoded certificates in addition to the system roots
+func WithCABundle(caBundle []byte) ClientOption {
+	return func(options *clientOptions) {
+		options.caBundle = caBundle
+	}
+}
+
+// WithRateLimit sets the initial request budget, until the account's plan or the api's
+// rate limit headers say otherwise
+func WithRateLimit(rateLimit RateLimit) ClientOption {
+	return func(options *clientOptions) {
+		options.rateLimit = rateLimit
+	}
+}
+
+// WithMaxRateLimitWait is how long a request may wait for the rate limiter
+// before failing with a RateLimitedError
+func WithMaxRateLimitWait(maxRateLimitWait time.Duration) ClientOption {
+	return func(options *clientOptions) {
+		options.maxRateLimitWait = maxRateLimitWait
+	}
+}
+
+func newClientOptions(options []ClientOption) clientOptions {
+	result := clientOptions{
+		baseUrl:          DefaultBaseUrl,
+		userAgent:        DefaultUserAgent,
+		rateLimit:        FreePlanRateLimit,
+		maxRateLimitWait: DefaultMaxRateLimitWait,
+		retryPolicy:      DefaultRetryPolicy,
+	}
+
+	for _, option := range options {
+		option(&result)
+	}
+
+	return result
+}
+
+func parseBaseUrl(baseUrl string) (string, error) {
+	parsed, err := url.Parse(baseUrl)
+	if err != nil {
+		return "", fmt.Errorf("invalid base url: %w", err)
+	}
+
+	if parsed.Scheme != "http" && parsed.Scheme != "https" {
+		return "", fmt.Errorf("invalid base url %q: scheme must be http or https", baseUrl)
+	}
+
+	if !strings.HasSuffix(baseUrl, "/") {
+		baseUrl = baseUrl + "/"
+	}
+
+	return baseUrl, nil
+}
+
+func buildHttpClient(options clientOptions) (*http.Client, error) {
+	if options.httpClient != nil {
+		if options.proxyUrl != nil || len(options.caBundle) > 0 {
+			return nil, errors.New("proxy and ca bundle options can't be combined with a custom http client")
+		}
+
+		httpClient := *options.httpClient
+		if options.timeout != 0 {
+			httpClient.Timeout = options.timeout
+		}
+
+		return &httpClient, nil
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if options.proxyUrl != nil {
+		transport.Proxy = http.ProxyURL(options.proxyUrl)
+	}
+
+	if len(options.caBundle) > 0 {
+		rootCAs, err := x509.SystemCertPool()
+		if err != nil {
+			rootCAs = x509.NewCertPool()
+		}
+
+		if !rootCAs.AppendCertsFromPEM(options.caBundle) {
+			return nil, errors.New("ca bundle contains no valid PEM certificates")
+		}
+
+		transport.TLSClientConfig = &tls.Config{
+			RootCAs:    rootCAs,
+			MinVersion: tls.VersionTLS12,
+		}
+	}
+
+	//the timeout is only left unset so an injected client keeps its own
+	timeout := options.timeout
+	if timeout == 0 {
+		timeout = DefaultTimeout
+	}
+
+	return &http.Client{
+		Transport: transport,
+		Timeout:   timeout,
+	}, nil
+}
@@ -0,0 +1,135 @@
+package uptimerobot
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+)
+
+const (
+	PSPTypeMonitors = 1
+
+	PSPSortFriendlyNameAsc  = 1
+	PSPSortFriendlyNameDesc = 2
+	PSPSortStatusUpFirst    = 3
+	PSPSortStatusDownFirst  = 4
+)
+
+type NewPSPRequest struct {
+	FriendlyName string `json:"friendly_name"`
+	// Monitors are the ids of the monitors shown, joined with "-"
+	Monitors     string `json:"monitors"`
+	CustomDomain string `json:"custom_domain"`
+	Password     string `json:"password"`
+	Sort         int    `json:"sort"`
+	HideUrlLinks bool   `json:"hide_url_links"`
+}
+
+type NewPSPResponse struct {
+	Stat string `json:"stat"`
+	PSP  struct {
+		Id int `json:"id"`
+	} `json:"psp"`
+}
+
+func (c NewPSPResponse) GetStat() string {
+	return c.Stat
+}
+
+type PSPCreator interface {
+	NewPSP(ctx context.Context, request NewPSPRequest) (NewPSPResponse, error)
+}
+
+type EditPSPRequest struct {
+	Id           string `json:"id"`
+	FriendlyName string `json:"friendly_name"`
+	Monitors     string `json:"monitors"`
+	CustomDomain string `json:"custom_domain"`
+	Password     string `json:"password"`
+	Sort         int    `json:"sort"`
+	HideUrlLinks bool   `json:"hide_url_links"`
+}
+
+type EditPSPResponse struct {
+	Stat string `json:"stat"`
+	PSP  struct {
+		Id int `json:"id"`
+	} `json:"psp"`
+}
+
+func (c EditPSPResponse) GetStat() string {
+	return c.Stat
+}
+
+type PSPEditor interface {
+	EditPSP(ctx context.Context, request EditPSPRequest) (EditPSPResponse, error)
+}
+
+type DeletePSPResponse struct {
+	Stat string `json:"stat"`
+	PSP  struct {
+		Id OptionalInt `json:"id"`
+	} `json:"psp"`
+}
+
+func (c DeletePSPResponse) GetStat() string {
+	return c.Stat
+}
+
+type PSPDeleter interface {
+	DeletePSP(ctx context.Context, id string) (DeletePSPResponse, error)
+}
+
+// PSPMonitors are the ids of the monitors a status page shows. getPSPs returns 0 instead of a list
+// for pages showing every monitor, which decodes as nil.
+type PSPMonitors []int
+
+func (monitors *PSPMonitors) UnmarshalJSON(data []byte) error {
+	if !bytes.HasPrefix(bytes.TrimSpace(data), []byte("[")) {
+		*monitors = nil
+		return nil
+	}
+
+	var ids []OptionalInt
+	err := json.Unmarshal(data, &ids)
+	if err != nil {
+		return err
+	}
+
+	*monitors = make(PSPMonitors, len(ids))
+	for i, id := range ids {
+		(*monitors)[i] = int(id)
+	}
+
+	return nil
+}
+
+type PSPDetails struct {
+	Id           OptionalInt `json:"id"`
+	FriendlyName string      `json:"friendly_name"`
+	Monitors     PSPMonitors `json:"monitors"`
+	Sort         OptionalInt `json:"sort"`
+	Status       int         `json:"status"`
+	StandardUrl  string      `json:"standard_url"`
+	CustomUrl    string      `json:"custom_url"`
+}
+
+type GetPSPsResponse struct {
+	Stat       string       `json:"stat"`
+	Pagination Pagination   `json:"pagination"`
+	PSPs       []PSPDetails `json:"psps"`
+}
+
+func (c GetPSPsResponse) GetStat() string {
+	return c.Stat
+}
+
+// ListPSPsOptions filters the status pages returned by getPSPs
+type ListPSPsOptions struct {
+	PSPIds []string
+}
+
+type PSPLister interface {
+	GetPSPsPage(ctx context.Context, options ListPSPsOptions, offset int, limit int) (GetPSPsResponse, error)
+	ListAllPSPs(ctx context.Context, options ListPSPsOptions, visit func(psp PSPDetails) error) error
+}
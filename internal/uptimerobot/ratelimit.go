@@ -0,0 +1,179 @@
+package uptimerobot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+const (
+	freePlanRequestsPerMinute = 10
+	maxRequestsPerMinute      = 5000
+	freePlanMonitorLimit      = 50
+
+	DefaultMaxRateLimitWait = 10 * time.Second
+)
+
+// RateLimit is the number of api requests per minute an UptimeRobot account may make
+type RateLimit struct {
+	RequestsPerMinute int
+}
+
+// FreePlanRateLimit is the most conservative limit and is used until the account's plan is known
+var FreePlanRateLimit = RateLimit{RequestsPerMinute: freePlanRequestsPerMinute}
+
+// RateLimitForMonitorLimit derives an account's rate limit from its plan's monitor limit.
+// Paid plans may make twice their monitor limit in requests per minute, up to 5000.
+func RateLimitForMonitorLimit(monitorLimit int) RateLimit {
+	if monitorLimit <= freePlanMonitorLimit {
+		return FreePlanRateLimit
+	}
+
+	requestsPerMinute := monitorLimit * 2
+	if requestsPerMinute > maxRequestsPerMinute {
+		requestsPerMinute = maxRequestsPerMinute
+	}
+
+	return RateLimit{RequestsPerMinute: requestsPerMinute}
+}
+
+func (limit RateLimit) limit() rate.Limit {
+	return rate.Limit(float64(limit.RequestsPerMinute) / 60)
+}
+
+// burst allows roughly ten seconds worth of requests at once
+func (limit RateLimit) burst() int {
+	burst := limit.RequestsPerMinute / 6
+	if burst < 1 {
+		return 1
+	}
+
+	return burst
+}
+
+// RateLimitedError is returned when a request can't be made without exceeding the account's rate limit
+type RateLimitedError struct {
+	RetryAfter time.Duration
+}
+
+func (err *RateLimitedError) Error() string {
+	return fmt.Sprintf("uptimerobot api rate limit reached, retry after %s", err.RetryAfter)
+}
+
+// RetryAfter reports how long to wait before retrying a request that was throttled
+func RetryAfter(err error) (time.Duration, bool) {
+	var rateLimitedError *RateLimitedError
+	if errors.As(err, &rateLimitedError) {
+		return rateLimitedError.RetryAfter, true
+	}
+
+	return 0, false
+}
+
+// SetRateLimit changes the request budget of the client, e.g. once the account's plan is known
+func (client *Client) SetRateLimit(limit RateLimit) {
+	if limit.RequestsPerMinute <= 0 {
+		return
+	}
+
+	if client.limiter.Limit() == limit.limit() {
+		return
+	}
+
+	client.limiter.SetLimit(limit.limit())
+	client.limiter.SetBurst(limit.burst())
+}
+
+func (client *Client) getBlockedUntil() time.Time {
+	client.mutex.RLock()
+	defer client.mutex.RUnlock()
+	return client.blockedUntil
+}
+
+func (client *Client) blockUntil(until time.Time) {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+	if until.After(client.blockedUntil) {
+		client.blockedUntil = until
+	}
+}
+
+// waitForRateLimit blocks until the request fits in the account's budget, giving up with a
+// RateLimitedError when that would take longer than the client is willing to wait
+func (client *Client) waitForRateLimit(ctx context.Context) error {
+	if blockedFor := time.Until(client.getBlockedUntil()); blockedFor > 0 {
+		return &RateLimitedError{RetryAfter: blockedFor}
+	}
+
+	reservation := client.limiter.Reserve()
+	delay := reservation.Delay()
+	if delay == 0 {
+		return nil
+	}
+
+	if delay > client.maxRateLimitWait {
+		reservation.Cancel()
+		return &RateLimitedError{RetryAfter: delay}
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		reservation.Cancel()
+		return ctx.Err()
+	}
+}
+
+// observeRateLimitHeaders follows the X-RateLimit-* and Retry-After headers of an api response,
+// returning a RateLimitedError when the api rejected the request for exceeding the limit
+func (client *Client) observeRateLimitHeaders(response *http.Response) error {
+	now := time.Now()
+	header := response.Header
+
+	if limit, err := strconv.Atoi(header.Get("X-RateLimit-Limit")); err == nil {
+		client.SetRateLimit(RateLimit{RequestsPerMinute: limit})
+	}
+
+	if remaining, err := strconv.Atoi(header.Get("X-RateLimit-Remaining")); err == nil && remaining <= 0 {
+		if reset, err := strconv.ParseInt(header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+			client.blockUntil(time.Unix(reset, 0))
+		}
+	}
+
+	if response.StatusCode != http.StatusTooManyRequests {
+		return nil
+	}
+
+	retryAfter := parseRetryAfter(header.Get("Retry-After"), now)
+	if retryAfter <= 0 {
+		retryAfter = time.Minute
+	}
+	client.blockUntil(now.Add(retryAfter))
+
+	return &RateLimitedError{RetryAfter: retryAfter}
+}
+
+// parseRetryAfter reads a Retry-After header given either in seconds or as an http date
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	if value == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(seconds) * time.Second
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		return date.Sub(now)
+	}
+
+	return 0
+}
@@ -0,0 +1,122 @@
+package uptimerobot
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/rand"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// RetryPolicy controls how api requests that fail transiently are retried
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first one
+	MaxAttempts int
+	// InitialBackoff is the delay before the first retry, it doubles with every retry after that
+	InitialBackoff time.Duration
+	// MaxBackoff caps the delay between two attempts
+	MaxBackoff time.Duration
+	// Jitter is the fraction of each delay, between 0 and 1, that is randomised
+	Jitter float64
+}
+
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:    3,
+	InitialBackoff: 500 * time.Millisecond,
+	MaxBackoff:     10 * time.Second,
+	Jitter:         0.2,
+}
+
+// WithRetryPolicy sets how transient api failures are retried
+func WithRetryPolicy(retryPolicy RetryPolicy) ClientOption {
+	return func(options *clientOptions) {
+		options.retryPolicy = retryPolicy
+	}
+}
+
+// backoff returns the delay before the given retry, counting from 1
+func (policy RetryPolicy) backoff(retry int) time.Duration {
+	backoff := policy.InitialBackoff
+	for i := 1; i < retry && backoff < policy.MaxBackoff; i++ {
+		backoff *= 2
+	}
+
+	if policy.MaxBackoff > 0 && backoff > policy.MaxBackoff {
+		backoff = policy.MaxBackoff
+	}
+
+	if policy.Jitter > 0 {
+		jitter := time.Duration(float64(backoff) * policy.Jitter * (rand.Float64()*2 - 1))
+		backoff += jitter
+	}
+
+	if backoff < 0 {
+		return 0
+	}
+
+	return backoff
+}
+
+// isIdempotent is true for api methods that can safely be repeated. Creating an object
+// twice would leave a duplicate behind so the new* methods are only retried when the
+// request never reached the api.
+func isIdempotent(methodName string) bool {
+	return !strings.HasPrefix(methodName, "new")
+}
+
+// isConnectionError is true when a request failed before it could be sent
+func isConnectionError(err error) bool {
+	var opError *net.OpError
+	if errors.As(err, &opError) {
+		return opError.Op == "dial"
+	}
+
+	var dnsError *net.DNSError
+	return errors.As(err, &dnsError)
+}
+
+// shouldRetryError decides if a request that failed without a response is worth repeating
+func shouldRetryError(ctx context.Context, methodName string, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+
+	var rateLimitedError *RateLimitedError
+	if errors.As(err, &rateLimitedError) {
+		return false
+	}
+
+	if isConnectionError(err) {
+		return true
+	}
+
+	return isIdempotent(methodName)
+}
+
+// shouldRetryResponse decides if a response is a transient failure worth repeating, like a
+// 5xx status or an html error page from a proxy in front of the api
+func shouldRetryResponse(methodName string, response apiResponse) bool {
+	if !isIdempotent(methodName) {
+		return false
+	}
+
+	if response.statusCode >= http.StatusInternalServerError {
+		return true
+	}
+
+	return !json.Valid(response.body)
+}
+
+func sleep(ctx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
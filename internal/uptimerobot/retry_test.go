@@ -0,0 +1,197 @@
+package uptimerobot
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+var testRetryPolicy = RetryPolicy{
+	MaxAttempts:    3,
+	InitialBackoff: time.Millisecond,
+	MaxBackoff:     5 * time.Millisecond,
+	Jitter:         0.5,
+}
+
+// newFlakyServer starts a stand-in api that fails the first failures requests with failure
+// before replying with body, returning a pointer to the number of requests received
+func newFlakyServer(t *testing.T, failures int, failure func(writer http.ResponseWriter), body string) (*httptest.Server, *int) {
+	t.Helper()
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		requests++
+		if requests <= failures {
+			failure(writer)
+			return
+		}
+		writer.Header().Set("content-type", "application/json")
+		fmt.Fprint(writer, body)
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &requests
+}
+
+func newRetryingClient(t *testing.T, options ...ClientOption) *Client {
+	t.Helper()
+	options = append([]ClientOption{
+		WithRetryPolicy(testRetryPolicy),
+		WithRateLimit(RateLimit{RequestsPerMinute: 6000}),
+	}, options...)
+	client, err := NewClient("key", options...)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	return client
+}
+
+func badGateway(writer http.ResponseWriter) {
+	writer.Header().Set("content-type", "text/html")
+	writer.WriteHeader(http.StatusBadGateway)
+	fmt.Fprint(writer, "<html><body>502 Bad Gateway</body></html>")
+}
+
+func htmlErrorPage(writer http.ResponseWriter) {
+	writer.Header().Set("content-type", "text/html")
+	fmt.Fprint(writer, "<html><body>Something went wrong</body></html>")
+}
+
+func TestReadsAreRetried(t *testing.T) {
+	testCases := []struct {
+		name    string
+		failure func(writer http.ResponseWriter)
+	}{
+		{"bad gateway", badGateway},
+		{"html error page", htmlErrorPage},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			server, requests := newFlakyServer(t, 2, testCase.failure, `{"stat":"ok","monitors":[{"id":"1"}]}`)
+			client := newRetryingClient(t, WithBaseUrl(server.URL))
+
+			response, err := client.GetMonitors(context.Background(), []string{"1"})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(response.Monitors) != 1 {
+				t.Errorf("expected 1 monitor, got %d", len(response.Monitors))
+			}
+
+			if *requests != 3 {
+				t.Errorf("expected 3 requests, got %d", *requests)
+			}
+		})
+	}
+}
+
+func TestRetriesGiveUpAfterMaxAttempts(t *testing.T) {
+	server, requests := newFlakyServer(t, 10, badGateway, `{"stat":"ok"}`)
+	client := newRetryingClient(t, WithBaseUrl(server.URL))
+
+	_, err := client.GetMonitors(context.Background(), []string{"1"})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	if *requests != testRetryPolicy.MaxAttempts {
+		t.Errorf("expected %d requests, got %d", testRetryPolicy.MaxAttempts, *requests)
+	}
+}
+
+func TestCreatesAreNotRetriedOnServerErrors(t *testing.T) {
+	server, requests := newFlakyServer(t, 1, badGateway, `{"stat":"ok","monitor":{"id":1}}`)
+	client := newRetryingClient(t, WithBaseUrl(server.URL))
+
+	_, err := client.NewMonitor(context.Background(), NewMonitorRequest{FriendlyName: "test"})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	if *requests != 1 {
+		t.Errorf("expected a create to be attempted once, got %d requests", *requests)
+	}
+}
+
+// failingTransport fails the first failures requests with err before handing over to the default transport
+type failingTransport struct {
+	failures int
+	err      error
+	attempts int
+}
+
+func (transport *failingTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	transport.attempts++
+	if transport.attempts <= transport.failures {
+		return nil, transport.err
+	}
+
+	return http.DefaultTransport.RoundTrip(request)
+}
+
+func TestCreatesAreRetriedWhenNeverSent(t *testing.T) {
+	server, requests := newFlakyServer(t, 0, badGateway, `{"stat":"ok","monitor":{"id":1}}`)
+	transport := &failingTransport{failures: 2, err: &net.OpError{Op: "dial", Net: "tcp", Err: fmt.Errorf("connection refused")}}
+	client := newRetryingClient(t, WithBaseUrl(server.URL), WithHttpClient(&http.Client{Transport: transport}))
+
+	response, err := client.NewMonitor(context.Background(), NewMonitorRequest{FriendlyName: "test"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if response.Monitor.Id != 1 {
+		t.Errorf("expected monitor 1, got %d", response.Monitor.Id)
+	}
+
+	if transport.attempts != 3 || *requests != 1 {
+		t.Errorf("expected 3 attempts and 1 request, got %d attempts and %d requests", transport.attempts, *requests)
+	}
+}
+
+func TestCreatesAreNotRetriedWhenPossiblySent(t *testing.T) {
+	server, _ := newFlakyServer(t, 0, badGateway, `{"stat":"ok","monitor":{"id":1}}`)
+	transport := &failingTransport{failures: 2, err: &net.OpError{Op: "read", Net: "tcp", Err: fmt.Errorf("connection reset by peer")}}
+	client := newRetryingClient(t, WithBaseUrl(server.URL), WithHttpClient(&http.Client{Transport: transport}))
+
+	_, err := client.NewMonitor(context.Background(), NewMonitorRequest{FriendlyName: "test"})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	if transport.attempts != 1 {
+		t.Errorf("expected a create to be attempted once, got %d attempts", transport.attempts)
+	}
+
+	_, err = client.GetMonitors(context.Background(), []string{"1"})
+	if err != nil {
+		t.Fatalf("expected a read to be retried, got %v", err)
+	}
+
+	if transport.attempts != 3 {
+		t.Errorf("expected the read to be attempted twice, got %d attempts in total", transport.attempts)
+	}
+}
+
+func TestBackoffIsCappedAndJittered(t *testing.T) {
+	policy := RetryPolicy{InitialBackoff: time.Second, MaxBackoff: 4 * time.Second, Jitter: 0.25}
+	for retry := 1; retry <= 10; retry++ {
+		backoff := policy.backoff(retry)
+		if backoff < 0 || backoff > 5*time.Second {
+			t.Errorf("retry %d: backoff %s outside of the jittered cap", retry, backoff)
+		}
+	}
+
+	policy.Jitter = 0
+	expected := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 4 * time.Second}
+	for i, expectedBackoff := range expected {
+		if backoff := policy.backoff(i + 1); backoff != expectedBackoff {
+			t.Errorf("retry %d: expected %s, got %s", i+1, expectedBackoff, backoff)
+		}
+	}
+}